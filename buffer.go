@@ -0,0 +1,142 @@
+package geom
+
+import (
+	"errors"
+	"runtime"
+
+	"github.com/vistarmedia/geom/geos-go"
+)
+
+var (
+	ErrInvalidBufferParams = errors.New("Invalid buffer params")
+)
+
+// Shape of the ends of buffered lines
+type CapStyle int
+
+const (
+	CAP_ROUND CapStyle = iota
+	CAP_FLAT
+	CAP_SQUARE
+)
+
+// Shape of the corners of buffered geometries
+type JoinStyle int
+
+const (
+	JOIN_ROUND JoinStyle = iota
+	JOIN_MITRE
+	JOIN_BEVEL
+)
+
+// Options for Geometry.BufferWithParams. The zero value produces the same
+// result as Geometry.Buffer with GEOS's default of 8 quadrant segments. A zero
+// MitreLimit or QuadrantSegments leaves the GEOS default in place.
+type BufferParams struct {
+	EndCapStyle CapStyle
+	JoinStyle   JoinStyle
+	// Ratio of the mitre length to the buffer width after which a mitre join is
+	// bevelled. Only used with JOIN_MITRE.
+	MitreLimit float64
+	// Number of segments used to approximate a quarter circle
+	QuadrantSegments int
+	// Only buffer one side of a line. Positive widths buffer to the left and
+	// negative widths to the right. End cap style is ignored.
+	SingleSided bool
+}
+
+func (c CapStyle) toGeos() (geos.BufCapStyle, error) {
+	switch c {
+	case CAP_ROUND:
+		return geos.CAP_ROUND, nil
+	case CAP_FLAT:
+		return geos.CAP_FLAT, nil
+	case CAP_SQUARE:
+		return geos.CAP_SQUARE, nil
+	default:
+		return 0, ErrInvalidBufferParams
+	}
+}
+
+func (j JoinStyle) toGeos() (geos.BufJoinStyle, error) {
+	switch j {
+	case JOIN_ROUND:
+		return geos.JOIN_ROUND, nil
+	case JOIN_MITRE:
+		return geos.JOIN_MITRE, nil
+	case JOIN_BEVEL:
+		return geos.JOIN_BEVEL, nil
+	default:
+		return 0, ErrInvalidBufferParams
+	}
+}
+
+func (bp BufferParams) toGeos(h *geos.Handle) (*geos.BufferParams, error) {
+	// Validated before the params are created so there is nothing to leak
+	capStyle, err := bp.EndCapStyle.toGeos()
+	if err != nil {
+		return nil, err
+	}
+	joinStyle, err := bp.JoinStyle.toGeos()
+	if err != nil {
+		return nil, err
+	}
+
+	params := geos.NewBufferParams(h)
+	err = params.SetEndCapStyle(h, capStyle)
+	if err == nil {
+		err = params.SetJoinStyle(h, joinStyle)
+	}
+	if err == nil && bp.MitreLimit != 0 {
+		err = params.SetMitreLimit(h, bp.MitreLimit)
+	}
+	if err == nil && bp.QuadrantSegments != 0 {
+		err = params.SetQuadrantSegments(h, bp.QuadrantSegments)
+	}
+	if err == nil {
+		err = params.SetSingleSided(h, bp.SingleSided)
+	}
+	if err != nil {
+		params.Destroy(h)
+		return nil, err
+	}
+	return params, nil
+}
+
+// Buffers the geometry by width with full control over end caps, joins and
+// sidedness.
+func (g *Geometry) BufferWithParams(
+	width float64, params BufferParams) (*Geometry, error) {
+
+	hp := g.provider()
+	h := hp.Get()
+	defer hp.Put(h)
+	defer runtime.KeepAlive(g)
+
+	bp, err := params.toGeos(h)
+	if err != nil {
+		return nil, err
+	}
+	defer bp.Destroy(h)
+
+	geom, err := g.g.BufferWithParams(h, bp, width)
+	return newGeometryOrError(hp, h, geom, err)
+}
+
+// Computes a line parallel to this LineString at the given distance. Positive
+// widths offset to the left and negative widths to the right. MitreLimit is
+// only used with JOIN_MITRE.
+func (g *Geometry) OffsetCurve(width float64, quadsegs int,
+	join JoinStyle, mitreLimit float64) (*Geometry, error) {
+
+	joinStyle, err := join.toGeos()
+	if err != nil {
+		return nil, err
+	}
+	hp := g.provider()
+	h := hp.Get()
+	defer hp.Put(h)
+	defer runtime.KeepAlive(g)
+	geom, err := g.g.OffsetCurve(h, width, quadsegs, joinStyle, mitreLimit)
+	return newGeometryOrError(hp, h, geom, err)
+}
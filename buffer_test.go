@@ -0,0 +1,113 @@
+package geom
+
+import (
+	"math"
+	"testing"
+)
+
+func TestBufferWithParamsFlatCap(t *testing.T) {
+	line, err := fact.NewLineString([]Coord{{0, 0}, {10, 0}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	round, err := line.BufferWithParams(1, BufferParams{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	flat, err := line.BufferWithParams(1, BufferParams{EndCapStyle: CAP_FLAT})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A flat capped corridor is exactly the 10x2 rectangle around the line
+	if flat.Area() != 20 {
+		t.Errorf("Expected area of 20, got %f", flat.Area())
+	}
+	if round.Area() <= flat.Area() {
+		t.Errorf("Round caps should be larger than flat caps, round: %f, flat: %f",
+			round.Area(), flat.Area())
+	}
+}
+
+func TestBufferWithParamsSingleSided(t *testing.T) {
+	line, err := fact.NewLineString([]Coord{{0, 0}, {10, 0}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	left, err := line.BufferWithParams(2, BufferParams{SingleSided: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if left.Area() != 20 {
+		t.Errorf("Expected area of 20, got %f", left.Area())
+	}
+	c0, c1, err := left.Bounds()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c0.Y != 0 || c1.Y != 2 {
+		t.Errorf("Expected buffer on the left side, got %v %v", c0, c1)
+	}
+}
+
+func TestBufferWithParamsMitre(t *testing.T) {
+	square, err := fact.NewPolygon([]Coord{
+		{0, 0},
+		{10, 0},
+		{10, 10},
+		{0, 10},
+		{0, 0},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	mitred, err := square.BufferWithParams(1, BufferParams{
+		JoinStyle:  JOIN_MITRE,
+		MitreLimit: 5,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mitred.Area() != 144 {
+		t.Errorf("Expected area of 144, got %f", mitred.Area())
+	}
+}
+
+func TestOffsetCurve(t *testing.T) {
+	line, err := fact.NewLineString([]Coord{{0, 0}, {10, 0}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	offset, err := line.OffsetCurve(-3, 8, JOIN_ROUND, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	coords, err := offset.LineString().Coords()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, c := range coords {
+		if math.Abs(c.Y+3) > 1e-9 {
+			t.Errorf("Expected all coords at y=-3, got %v", coords)
+		}
+	}
+}
+
+func TestBufferWithInvalidParams(t *testing.T) {
+	line, err := fact.NewLineString([]Coord{{0, 0}, {10, 0}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = line.BufferWithParams(1, BufferParams{EndCapStyle: CapStyle(7)})
+	if err != ErrInvalidBufferParams {
+		t.Errorf("Expected ErrInvalidBufferParams, got %v", err)
+	}
+	_, err = line.BufferWithParams(1, BufferParams{JoinStyle: JoinStyle(-1)})
+	if err != ErrInvalidBufferParams {
+		t.Errorf("Expected ErrInvalidBufferParams, got %v", err)
+	}
+	if _, err = line.OffsetCurve(1, 8, JoinStyle(3), 0); err != ErrInvalidBufferParams {
+		t.Errorf("Expected ErrInvalidBufferParams, got %v", err)
+	}
+}
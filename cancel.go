@@ -0,0 +1,123 @@
+package geom
+
+import (
+	"context"
+	"runtime"
+
+	"github.com/vistarmedia/geom/geos-go"
+	"github.com/vistarmedia/geom/geos-go/handle"
+)
+
+// Runs op with a leased handle, aborting it if ctx is done first. If op fails
+// because it was aborted, ctx.Err() is returned.
+func interruptible(ctx context.Context, hp handle.GeosHandleProvider,
+	op func(*geos.Handle) (*geos.Geometry, error)) (*Geometry, error) {
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	intr := geos.NewInterrupt()
+	defer intr.Destroy()
+	stop := make(chan struct{})
+	watching := make(chan struct{})
+	go func() {
+		defer close(watching)
+		select {
+		case <-ctx.Done():
+			intr.Request()
+		case <-stop:
+		}
+	}()
+
+	var (
+		g   *geos.Geometry
+		err error
+	)
+	h := hp.Get()
+	defer hp.Put(h)
+	intr.Run(h, func() {
+		g, err = op(h)
+	})
+
+	// The watcher must be finished with the interrupt before it is destroyed
+	close(stop)
+	<-watching
+
+	if err != nil && ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	return newGeometryOrError(hp, h, g, err)
+}
+
+// Like Intersection, but returns ctx.Err() if ctx is done before the
+// intersection completes.
+func (g *Geometry) IntersectionCtx(
+	ctx context.Context, o toGeos) (*Geometry, error) {
+
+	return g.interruptibleBinary(ctx, g.g.Intersection, o)
+}
+
+// Like Union, but returns ctx.Err() if ctx is done before the union completes.
+func (g *Geometry) UnionCtx(ctx context.Context, o toGeos) (*Geometry, error) {
+	return g.interruptibleBinary(ctx, g.g.Union, o)
+}
+
+// Like UnaryUnion, but returns ctx.Err() if ctx is done before the union
+// completes.
+func (g *Geometry) UnaryUnionCtx(ctx context.Context) (*Geometry, error) {
+	defer runtime.KeepAlive(g)
+	return interruptible(ctx, g.provider(), g.g.UnaryUnion)
+}
+
+// Like Buffer, but returns ctx.Err() if ctx is done before the buffer
+// completes.
+func (g *Geometry) BufferCtx(
+	ctx context.Context, width float64, quadsegs int) (*Geometry, error) {
+
+	defer runtime.KeepAlive(g)
+	return interruptible(ctx, g.provider(), func(h *geos.Handle) (*geos.Geometry, error) {
+		return g.g.Buffer(h, width, quadsegs)
+	})
+}
+
+// Like BufferWithParams, but returns ctx.Err() if ctx is done before the
+// buffer completes.
+func (g *Geometry) BufferWithParamsCtx(ctx context.Context,
+	width float64, params BufferParams) (*Geometry, error) {
+
+	defer runtime.KeepAlive(g)
+	return interruptible(ctx, g.provider(), func(h *geos.Handle) (*geos.Geometry, error) {
+		bp, err := params.toGeos(h)
+		if err != nil {
+			return nil, err
+		}
+		defer bp.Destroy(h)
+		return g.g.BufferWithParams(h, bp, width)
+	})
+}
+
+// Like UnionAll, but returns ctx.Err() if ctx is done before the union
+// completes.
+func (f Factory) UnionAllCtx(
+	ctx context.Context, gs []*Geometry) (*Geometry, error) {
+
+	return interruptible(ctx, f.hp, func(h *geos.Handle) (*geos.Geometry, error) {
+		coll, err := newGeosCollection(h, geos.GEOMETRYCOLLECTION, gs)
+		if err != nil {
+			return nil, err
+		}
+		defer coll.Destroy(h)
+		return coll.UnaryUnion(h)
+	})
+}
+
+func (g *Geometry) interruptibleBinary(
+	ctx context.Context, op binaryOp, o toGeos) (*Geometry, error) {
+
+	defer runtime.KeepAlive(g)
+	defer runtime.KeepAlive(o)
+	return interruptible(ctx, g.provider(), func(h *geos.Handle) (*geos.Geometry, error) {
+		return op(h, o.UnsafeToGeos())
+	})
+}
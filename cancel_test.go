@@ -0,0 +1,83 @@
+package geom
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+)
+
+// A long zig-zag line which is expensive to buffer
+func zigZag(t *testing.T, n int) *Geometry {
+	coords := make([]Coord, n)
+	for i := range coords {
+		coords[i] = Coord{float64(i), math.Mod(float64(i), 2)}
+	}
+	line, err := fact.NewLineString(coords)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return line.Geometry
+}
+
+func TestBufferCtx(t *testing.T) {
+	square, err := fact.NewPolygon([]Coord{
+		{0, 0},
+		{10, 0},
+		{10, 10},
+		{0, 10},
+		{0, 0},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	buf, err := square.BufferCtx(context.Background(), 1, 8)
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp, err := square.Buffer(1, 8)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if buf.Area() != exp.Area() {
+		t.Errorf("Expected area of %f, got %f", exp.Area(), buf.Area())
+	}
+}
+
+func TestBufferCtxCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := zigZag(t, 10).BufferCtx(ctx, 1, 8); err != context.Canceled {
+		t.Errorf("Expected %v, got %v", context.Canceled, err)
+	}
+}
+
+func TestBufferCtxDeadline(t *testing.T) {
+	line := zigZag(t, 100000)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := line.BufferCtx(ctx, 3, 1000)
+	if err != context.DeadlineExceeded {
+		t.Fatalf("Expected %v, got %v", context.DeadlineExceeded, err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Buffer took %s to abort", elapsed)
+	}
+
+	// Other operations are unaffected once the interrupt has been handled
+	if _, err := zigZag(t, 10).BufferCtx(context.Background(), 1, 8); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestUnionAllCtx(t *testing.T) {
+	union, err := fact.UnionAllCtx(context.Background(), squareGrid(t, 3, 0.5))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if union.Area() != 3.5*3.5 {
+		t.Errorf("Expected area of %f, got %f", 3.5*3.5, union.Area())
+	}
+}
@@ -2,27 +2,97 @@
 package context
 
 import (
+	"encoding/binary"
+
 	"github.com/vistarmedia/geom"
+	"github.com/vistarmedia/geom/encoding/geojson"
 	"github.com/vistarmedia/geom/encoding/wkb"
 	"github.com/vistarmedia/geom/encoding/wkt"
 	"github.com/vistarmedia/geom/geos-go/handle"
 )
 
 type Context struct {
-	hp handle.GeosHandleProvider
+	hp          handle.GeosHandleProvider
+	factoryOpts []geom.FactoryOption
+	quadsegs    int
+	wktOpts     []wkt.EncoderOption
+	wkbOpts     []wkb.EncoderOption
+}
+
+// Configures a Context
+type Option func(*Context)
+
+// Leases handles from hp rather than a new PooledHandleProvider
+func WithHandleProvider(hp handle.GeosHandleProvider) Option {
+	return func(ctx *Context) {
+		ctx.hp = hp
+	}
+}
+
+// Snaps geometries built or decoded through the context to a grid. See
+// geom.WithPrecision.
+func WithPrecision(gridSize float64) Option {
+	return func(ctx *Context) {
+		ctx.factoryOpts = append(ctx.factoryOpts, geom.WithPrecision(gridSize))
+	}
+}
+
+// Sets the SRID of geometries built or decoded through the context. See
+// geom.WithSRID.
+func WithSRID(srid int) Option {
+	return func(ctx *Context) {
+		ctx.factoryOpts = append(ctx.factoryOpts, geom.WithSRID(srid))
+	}
+}
+
+// Number of segments per quarter circle used by Buffer and BufferParams
+func WithQuadSegs(quadsegs int) Option {
+	return func(ctx *Context) {
+		ctx.quadsegs = quadsegs
+	}
+}
+
+func WithWKTOptions(opts ...wkt.EncoderOption) Option {
+	return func(ctx *Context) {
+		ctx.wktOpts = append(ctx.wktOpts, opts...)
+	}
+}
+
+func WithWKBOptions(opts ...wkb.EncoderOption) Option {
+	return func(ctx *Context) {
+		ctx.wkbOpts = append(ctx.wkbOpts, opts...)
+	}
 }
 
-// Only one context should be instantiated per application.
-func NewContext() Context {
-	return Context{handle.NewPooledHandleProvider()}
+// Contexts sharing a handle provider share its handles, so an application
+// should prefer one context per set of options, created at startup.
+func NewContext(opts ...Option) Context {
+	var ctx Context
+	for _, opt := range opts {
+		opt(&ctx)
+	}
+	if ctx.hp == nil {
+		ctx.hp = handle.NewPooledHandleProvider()
+	}
+	return ctx
 }
 
 func (ctx Context) Factory() geom.Factory {
-	return geom.NewFactory(ctx.hp)
+	return geom.NewFactory(ctx.hp, ctx.factoryOpts...)
+}
+
+// Default buffer parameters, using the context's quadrant segments
+func (ctx Context) BufferParams() geom.BufferParams {
+	return geom.BufferParams{QuadrantSegments: ctx.quadsegs}
+}
+
+// Buffers g by width with the context's default buffer parameters
+func (ctx Context) Buffer(g *geom.Geometry, width float64) (*geom.Geometry, error) {
+	return g.BufferWithParams(width, ctx.BufferParams())
 }
 
 func (ctx Context) WKTEncoder() *wkt.Encoder {
-	return wkt.NewEncoder(ctx.hp)
+	return wkt.NewEncoder(ctx.hp, ctx.wktOpts...)
 }
 
 func (ctx Context) WKTDecoder() *wkt.Decoder {
@@ -30,9 +100,28 @@ func (ctx Context) WKTDecoder() *wkt.Decoder {
 }
 
 func (ctx Context) WKBEncoder() *wkb.Encoder {
-	return wkb.NewEncoder(ctx.hp)
+	return wkb.NewEncoder(ctx.hp, ctx.wkbOpts...)
 }
 
 func (ctx Context) WKBDecoder() *wkb.Decoder {
 	return wkb.NewDecoder(ctx.hp, ctx.Factory())
 }
+
+// Decodes WKB and EWKB in Go, building geometries with the context's factory
+func (ctx Context) GoWKBDecoder() *wkb.GoDecoder {
+	return wkb.NewGoDecoder(ctx.Factory())
+}
+
+// Encodes little-endian WKB in Go, or EWKB for geometries with an SRID. The
+// context's WKB options only apply to WKBEncoder.
+func (ctx Context) GoWKBEncoder() *wkb.GoEncoder {
+	return wkb.NewGoEncoder(binary.LittleEndian)
+}
+
+func (ctx Context) GeoJSONEncoder() geojson.Encoder {
+	return geojson.NewEncoder()
+}
+
+func (ctx Context) GeoJSONDecoder() geojson.Decoder {
+	return geojson.NewDecoder(ctx.Factory())
+}
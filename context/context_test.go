@@ -2,9 +2,13 @@ package context
 
 import (
 	"fmt"
+	"math"
 	"testing"
 
 	"github.com/vistarmedia/geom"
+	"github.com/vistarmedia/geom/encoding/wkb"
+	"github.com/vistarmedia/geom/encoding/wkt"
+	"github.com/vistarmedia/geom/geos-go/handle"
 )
 
 func TestExample(t *testing.T) {
@@ -13,3 +17,66 @@ func TestExample(t *testing.T) {
 	point, _ := fact.NewPoint(geom.Coord{4, 2})
 	fmt.Println(ctx.WKTEncoder().Encode(point))
 }
+
+func TestOptions(t *testing.T) {
+	ctx := NewContext(
+		WithPrecision(1),
+		WithSRID(4326),
+		WithQuadSegs(1),
+		WithWKTOptions(wkt.Trim(true)),
+		WithWKBOptions(wkb.IncludeSRID(true)))
+
+	g, err := ctx.GeoJSONDecoder().Decode(
+		[]byte(`{"type":"Point","coordinates":[1.2,3.7]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if srid := g.SRID(); srid != 4326 {
+		t.Errorf("Expected SRID 4326, got %d", srid)
+	}
+	if enc := ctx.WKTEncoder().Encode(g); enc != "POINT (1 4)" {
+		t.Errorf("Expected snapped point, got %s", enc)
+	}
+
+	decoded, err := ctx.WKBDecoder().Decode(ctx.WKBEncoder().Encode(g))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if srid := decoded.SRID(); srid != 4326 {
+		t.Errorf("Expected SRID to round trip EWKB, got %d", srid)
+	}
+
+	goDecoded, err := ctx.GoWKBDecoder().Decode(ctx.WKBEncoder().Encode(g))
+	if err != nil {
+		t.Fatal(err)
+	}
+	goEncoded, err := ctx.GoWKBEncoder().Encode(goDecoded)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if decoded, err = ctx.WKBDecoder().Decode(goEncoded); err != nil {
+		t.Fatal(err)
+	} else if eq, _ := decoded.Equals(g); !eq || decoded.SRID() != 4326 {
+		t.Error("Expected the Go WKB codecs to round trip")
+	}
+
+	// 1 segment per quadrant gives a square rotated 45 degrees
+	buffered, err := ctx.Buffer(g, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if area := buffered.Area(); math.Abs(area-2) > 1e-9 {
+		t.Errorf("Expected area of 2, got %f", area)
+	}
+}
+
+func TestWithHandleProvider(t *testing.T) {
+	ip := handle.NewInstrumentedHandleProvider(handle.NewPooledHandleProvider())
+	ctx := NewContext(WithHandleProvider(ip))
+	if _, err := ctx.Factory().NewPoint(geom.Coord{4, 2}); err != nil {
+		t.Fatal(err)
+	}
+	if ip.Stats().Gets == 0 {
+		t.Error("Expected the context to lease from the provider")
+	}
+}
@@ -0,0 +1,326 @@
+// Package ewkb reads and writes Well Known Binary and PostGIS Extended WKB in
+// pure Go, without libgeos. Geometries are shape values; see the wkb package
+// to convert them to and from geom.Geometry.
+package ewkb
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/vistarmedia/geom/shape"
+)
+
+type Type uint32
+
+const (
+	POINT              Type = 1
+	LINESTRING         Type = 2
+	POLYGON            Type = 3
+	MULTIPOINT         Type = 4
+	MULTILINESTRING    Type = 5
+	MULTIPOLYGON       Type = 6
+	GEOMETRYCOLLECTION Type = 7
+)
+
+// EWKB type flags
+const (
+	flagZ    = 0x80000000
+	flagM    = 0x40000000
+	flagSRID = 0x20000000
+)
+
+var (
+	ErrTruncated = errors.New("ewkb: Truncated input")
+	ErrByteOrder = errors.New("ewkb: Invalid byte order")
+	ErrTooDeep   = errors.New("ewkb: Collections nested too deeply")
+	ErrMember    = errors.New("ewkb: Collection member of the wrong type")
+)
+
+// Deepest nesting of collections Unmarshal accepts
+const MaxDepth = 64
+
+type ErrUnsupportedType uint32
+
+func (e ErrUnsupportedType) Error() string {
+	return fmt.Sprintf("ewkb: Unsupported type %d", uint32(e))
+}
+
+// Parses WKB or EWKB, returning the SRID, or 0 if unset. Z and M values are
+// discarded and polygon rings are returned as LinearRings.
+func Unmarshal(b []byte) (shape.Shape, int, error) {
+	r := reader{b: b}
+	s := r.geometry()
+	if r.err == nil && len(r.b) > 0 {
+		r.err = fmt.Errorf("ewkb: %d trailing bytes", len(r.b))
+	}
+	if r.err != nil {
+		return nil, 0, r.err
+	}
+	return s, r.srid, nil
+}
+
+// Writes s as WKB in the given byte order, or as EWKB if srid is not 0.
+// LinearRings are written as LineStrings and members of collections are written
+// without SRIDs.
+func Marshal(s shape.Shape, srid int, order binary.ByteOrder) ([]byte, error) {
+	w := writer{order: order}
+	w.geometry(s, srid)
+	return w.b, w.err
+}
+
+type reader struct {
+	b   []byte
+	err error
+	// SRID of the outermost geometry
+	srid int
+	// Collections entered so far
+	depth int
+}
+
+func (r *reader) read(n int) []byte {
+	if r.err != nil {
+		return nil
+	}
+	if len(r.b) < n {
+		r.err = ErrTruncated
+		return nil
+	}
+	b := r.b[:n]
+	r.b = r.b[n:]
+	return b
+}
+
+func (r *reader) geometry() shape.Shape {
+	b := r.read(1)
+	if b == nil {
+		return nil
+	}
+	var order binary.ByteOrder
+	switch b[0] {
+	case 0:
+		order = binary.BigEndian
+	case 1:
+		order = binary.LittleEndian
+	default:
+		r.err = ErrByteOrder
+		return nil
+	}
+	readUint32 := func() uint32 {
+		if b := r.read(4); b != nil {
+			return order.Uint32(b)
+		}
+		return 0
+	}
+
+	typ := readUint32()
+	dims := 2
+	if typ&flagZ != 0 {
+		dims++
+	}
+	if typ&flagM != 0 {
+		dims++
+	}
+	if typ&flagSRID != 0 {
+		srid := int(int32(readUint32()))
+		if r.depth == 0 {
+			r.srid = srid
+		}
+	}
+	typ &^= flagZ | flagM | flagSRID
+	// ISO WKB encodes dimensions in the thousands
+	switch typ / 1000 {
+	case 1, 2:
+		dims = 3
+	case 3:
+		dims = 4
+	}
+
+	coord := func() shape.Coord {
+		b := r.read(8 * dims)
+		if b == nil {
+			return shape.Coord{}
+		}
+		return shape.Coord{
+			X: math.Float64frombits(order.Uint64(b)),
+			Y: math.Float64frombits(order.Uint64(b[8:])),
+		}
+	}
+	// Guards against allocating for counts larger than the remaining input
+	count := func(minSize int) int {
+		n := readUint32()
+		if r.err == nil && uint64(n)*uint64(minSize) > uint64(len(r.b)) {
+			r.err = ErrTruncated
+			return 0
+		}
+		return int(n)
+	}
+	coords := func() []shape.Coord {
+		cs := make([]shape.Coord, count(8*dims))
+		for i := range cs {
+			cs[i] = coord()
+		}
+		return cs
+	}
+
+	var s shape.Shape
+	switch t := Type(typ % 1000); t {
+	case POINT:
+		// Empty points are written with NaN coordinates, as in shape
+		s = shape.Point(coord())
+	case LINESTRING:
+		s = shape.LineString(coords())
+	case POLYGON:
+		p := make(shape.Polygon, count(4))
+		for i := range p {
+			p[i] = coords()
+		}
+		s = p
+	case MULTIPOINT, MULTILINESTRING, MULTIPOLYGON, GEOMETRYCOLLECTION:
+		if r.depth++; r.depth > MaxDepth {
+			r.err = ErrTooDeep
+			break
+		}
+		// The smallest member is an empty collection's header
+		members := make(shape.Collection, count(9))
+		for i := range members {
+			if members[i] = r.geometry(); r.err != nil {
+				break
+			}
+		}
+		r.depth--
+		if r.err == nil {
+			s = r.collection(t, members)
+		}
+	default:
+		if r.err == nil {
+			r.err = ErrUnsupportedType(typ)
+		}
+	}
+	if r.err != nil {
+		return nil
+	}
+	return s
+}
+
+// Narrows the members of a collection to the shape type for t
+func (r *reader) collection(t Type, members shape.Collection) shape.Shape {
+	var ok bool
+	switch t {
+	case MULTIPOINT:
+		mp := make(shape.MultiPoint, len(members))
+		for i, m := range members {
+			if mp[i], ok = m.(shape.Point); !ok {
+				r.err = ErrMember
+			}
+		}
+		return mp
+	case MULTILINESTRING:
+		mls := make(shape.MultiLineString, len(members))
+		for i, m := range members {
+			if mls[i], ok = m.(shape.LineString); !ok {
+				r.err = ErrMember
+			}
+		}
+		return mls
+	case MULTIPOLYGON:
+		mp := make(shape.MultiPolygon, len(members))
+		for i, m := range members {
+			if mp[i], ok = m.(shape.Polygon); !ok {
+				r.err = ErrMember
+			}
+		}
+		return mp
+	default:
+		return members
+	}
+}
+
+type writer struct {
+	b     []byte
+	order binary.ByteOrder
+	err   error
+}
+
+func (w *writer) uint32(n uint32) {
+	var b [4]byte
+	w.order.PutUint32(b[:], n)
+	w.b = append(w.b, b[:]...)
+}
+
+func (w *writer) coord(c shape.Coord) {
+	var b [16]byte
+	w.order.PutUint64(b[:], math.Float64bits(c.X))
+	w.order.PutUint64(b[8:], math.Float64bits(c.Y))
+	w.b = append(w.b, b[:]...)
+}
+
+func (w *writer) coords(cs []shape.Coord) {
+	w.uint32(uint32(len(cs)))
+	for _, c := range cs {
+		w.coord(c)
+	}
+}
+
+func (w *writer) header(t Type, srid int) {
+	if w.order == binary.BigEndian {
+		w.b = append(w.b, 0)
+	} else {
+		w.b = append(w.b, 1)
+	}
+	typ := uint32(t)
+	if srid != 0 {
+		typ |= flagSRID
+	}
+	w.uint32(typ)
+	if srid != 0 {
+		w.uint32(uint32(int32(srid)))
+	}
+}
+
+func (w *writer) geometry(s shape.Shape, srid int) {
+	switch s := s.(type) {
+	case shape.Point:
+		w.header(POINT, srid)
+		w.coord(shape.Coord(s))
+	case shape.LineString:
+		w.header(LINESTRING, srid)
+		w.coords(s)
+	case shape.LinearRing:
+		w.header(LINESTRING, srid)
+		w.coords(s)
+	case shape.Polygon:
+		w.header(POLYGON, srid)
+		w.uint32(uint32(len(s)))
+		for _, ring := range s {
+			w.coords(ring)
+		}
+	case shape.MultiPoint:
+		w.header(MULTIPOINT, srid)
+		w.uint32(uint32(len(s)))
+		for _, p := range s {
+			w.geometry(p, 0)
+		}
+	case shape.MultiLineString:
+		w.header(MULTILINESTRING, srid)
+		w.uint32(uint32(len(s)))
+		for _, ls := range s {
+			w.geometry(ls, 0)
+		}
+	case shape.MultiPolygon:
+		w.header(MULTIPOLYGON, srid)
+		w.uint32(uint32(len(s)))
+		for _, p := range s {
+			w.geometry(p, 0)
+		}
+	case shape.Collection:
+		w.header(GEOMETRYCOLLECTION, srid)
+		w.uint32(uint32(len(s)))
+		for _, m := range s {
+			w.geometry(m, 0)
+		}
+	default:
+		w.err = shape.ErrUnsupportedShape
+	}
+}
@@ -0,0 +1,148 @@
+package ewkb
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"testing"
+
+	"github.com/vistarmedia/geom/shape"
+)
+
+func mustDecodeHex(t *testing.T, s string) []byte {
+	t.Helper()
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+func assertUnmarshal(t *testing.T, s string, exp shape.Shape, expSRID int) {
+	t.Helper()
+	act, srid, err := Unmarshal(mustDecodeHex(t, s))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !shape.Equal(exp, act) || srid != expSRID {
+		t.Errorf("Expected %v with SRID %d, got %v with SRID %d",
+			exp, expSRID, act, srid)
+	}
+}
+
+func TestUnmarshalPoint(t *testing.T) {
+	// Matches the GEOS writer's output in the wkb package's tests
+	assertUnmarshal(t, "010100000000000000000000400000000000001040",
+		shape.Point{X: 2, Y: 4}, 0)
+}
+
+func TestUnmarshalBigEndian(t *testing.T) {
+	// https://en.wikipedia.org/wiki/Well-known_text#Well-known_binary
+	assertUnmarshal(t, "000000000140000000000000004010000000000000",
+		shape.Point{X: 2, Y: 4}, 0)
+}
+
+func TestUnmarshalEWKB(t *testing.T) {
+	// SRID=4326;POINT(1 2), from PostGIS
+	assertUnmarshal(t, "0101000020E6100000000000000000F03F0000000000000040",
+		shape.Point{X: 1, Y: 2}, 4326)
+}
+
+func TestUnmarshalZ(t *testing.T) {
+	// ISO POINT Z (1 2 3) and EWKB POINT(1 2 3)
+	for _, s := range []string{
+		"01E9030000000000000000F03F00000000000000400000000000000840",
+		"0101000080000000000000F03F00000000000000400000000000000840",
+	} {
+		assertUnmarshal(t, s, shape.Point{X: 1, Y: 2}, 0)
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	square := shape.LinearRing{{X: 0, Y: 0}, {X: 4, Y: 0}, {X: 4, Y: 4},
+		{X: 0, Y: 4}, {X: 0, Y: 0}}
+	hole := shape.LinearRing{{X: 1, Y: 1}, {X: 2, Y: 1}, {X: 2, Y: 2},
+		{X: 1, Y: 1}}
+	line := shape.LineString{{X: 0, Y: 0}, {X: 1, Y: 1}}
+	for _, tc := range []struct {
+		s    shape.Shape
+		srid int
+	}{
+		{shape.Point{X: 1, Y: 2}, 0},
+		{shape.EmptyPoint(), 0},
+		{line, 0},
+		{shape.Polygon{square, hole}, 3857},
+		{shape.Polygon{}, 0},
+		{shape.MultiPoint{{X: 1, Y: 2}, {X: 3, Y: 4}}, 0},
+		{shape.MultiLineString{line}, 0},
+		{shape.MultiPolygon{{square}}, 4326},
+		{shape.Collection{line, shape.MultiPolygon{}}, 0},
+	} {
+		for _, order := range []binary.ByteOrder{binary.LittleEndian, binary.BigEndian} {
+			b, err := Marshal(tc.s, tc.srid, order)
+			if err != nil {
+				t.Fatal(err)
+			}
+			act, srid, err := Unmarshal(b)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !shape.Equal(tc.s, act) || srid != tc.srid {
+				t.Errorf("Expected %v with SRID %d, got %v with SRID %d",
+					tc.s, tc.srid, act, srid)
+			}
+		}
+	}
+}
+
+func TestMarshalLinearRing(t *testing.T) {
+	ring := shape.LinearRing{{X: 0, Y: 0}, {X: 1, Y: 0}, {X: 1, Y: 1},
+		{X: 0, Y: 0}}
+	b, err := Marshal(ring, 0, binary.LittleEndian)
+	if err != nil {
+		t.Fatal(err)
+	}
+	act, _, err := Unmarshal(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !shape.Equal(shape.LineString(ring), act) {
+		t.Errorf("Expected a LineString, got %v", act)
+	}
+	if _, err := Marshal(nil, 0, binary.LittleEndian); err != shape.ErrUnsupportedShape {
+		t.Errorf("Expected ErrUnsupportedShape, got %v", err)
+	}
+}
+
+func TestUnmarshalErrors(t *testing.T) {
+	for s, exp := range map[string]error{
+		"":               ErrTruncated,
+		"0201000000":     ErrByteOrder,
+		"0101000000":     ErrTruncated,
+		"0108000000":     ErrUnsupportedType(8),
+		"0102000000ffff": ErrTruncated,
+		// A linestring claiming a billion coordinates
+		"010200000000ca9a3b": ErrTruncated,
+		// A multipoint holding an empty linestring
+		"010400000001000000010200000000000000": ErrMember,
+	} {
+		if _, _, err := Unmarshal(mustDecodeHex(t, s)); err != exp {
+			t.Errorf("%s: Expected %v, got %v", s, exp, err)
+		}
+	}
+}
+
+func TestUnmarshalDepth(t *testing.T) {
+	nested := func(depth int) []byte {
+		var b []byte
+		for i := 1; i < depth; i++ {
+			b = append(b, mustDecodeHex(t, "010700000001000000")...)
+		}
+		return append(b, mustDecodeHex(t, "010700000000000000")...)
+	}
+	if _, _, err := Unmarshal(nested(MaxDepth)); err != nil {
+		t.Errorf("Expected %d levels to decode, got %v", MaxDepth, err)
+	}
+	if _, _, err := Unmarshal(nested(MaxDepth + 1)); err != ErrTooDeep {
+		t.Errorf("Expected ErrTooDeep, got %v", err)
+	}
+}
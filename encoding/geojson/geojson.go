@@ -1,4 +1,4 @@
-// Package geojson implements basic GeoJSON decoding.
+// Package geojson implements basic GeoJSON encoding and decoding.
 package geojson
 
 import (
@@ -118,3 +118,96 @@ func (d Decoder) decodeMultipolygon(coords json.RawMessage) (g geom.Multipolygon
 
 	return d.geoFact.NewMultipolygon(polys...)
 }
+
+// -----------------------------------------------------------------------------
+// Encoder
+
+// Writes GeoJSON geometry objects. Supports the same types as Decoder.
+type Encoder struct{}
+
+func NewEncoder() Encoder {
+	return Encoder{}
+}
+
+func (e Encoder) Encode(g *geom.Geometry) ([]byte, error) {
+	m := struct {
+		Type        string      `json:"type"`
+		Coordinates interface{} `json:"coordinates"`
+	}{}
+	var err error
+	switch g.Type() {
+	case geom.POINT:
+		m.Type = "Point"
+		m.Coordinates, err = e.encodePoint(g.Point())
+	case geom.POLYGON:
+		m.Type = "Polygon"
+		m.Coordinates, err = e.encodePolygon(g.Polygon())
+	case geom.MULTIPOLYGON:
+		m.Type = "MultiPolygon"
+		m.Coordinates, err = e.encodeMultipolygon(g)
+	default:
+		return nil, ErrUnsupportedType(fmt.Sprintf("%d", g.Type()))
+	}
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(m)
+}
+
+func (e Encoder) encodePoint(p geom.Point) ([]float64, error) {
+	if isEmpty, err := p.IsEmpty(); err != nil || isEmpty {
+		return []float64{}, err
+	}
+	c, err := p.Coord()
+	if err != nil {
+		return nil, err
+	}
+	return []float64{c.X, c.Y}, nil
+}
+
+func (e Encoder) encodePolygon(p geom.Polygon) ([][][2]float64, error) {
+	ps := [][][2]float64{}
+	if isEmpty, err := p.IsEmpty(); err != nil || isEmpty {
+		return ps, err
+	}
+	shell, err := p.Shell()
+	if err != nil {
+		return nil, err
+	}
+	holes, err := p.Holes()
+	if err != nil {
+		return nil, err
+	}
+	ps = append(ps, encodeRing(shell))
+	for _, hole := range holes {
+		ps = append(ps, encodeRing(hole))
+	}
+	return ps, nil
+}
+
+func (e Encoder) encodeMultipolygon(g *geom.Geometry) ([][][][2]float64, error) {
+	ps := [][][][2]float64{}
+	if isEmpty, err := g.IsEmpty(); err != nil || isEmpty {
+		return ps, err
+	}
+	polys, err := g.Geometries()
+	if err != nil {
+		return nil, err
+	}
+	for _, poly := range polys {
+		p, err := e.encodePolygon(poly.Polygon())
+		if err != nil {
+			return nil, err
+		}
+		ps = append(ps, p)
+	}
+	return ps, nil
+}
+
+func encodeRing(coords []geom.Coord) [][2]float64 {
+	ring := make([][2]float64, len(coords))
+	for i, c := range coords {
+		ring[i] = [2]float64{c.X, c.Y}
+	}
+	return ring
+}
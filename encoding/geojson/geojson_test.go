@@ -1,24 +1,40 @@
 package geojson
 
 import (
-	"strings"
 	"testing"
 
 	"github.com/vistarmedia/geom"
-	geomcontext "github.com/vistarmedia/geom/context"
+	wktenc "github.com/vistarmedia/geom/encoding/wkt"
+	"github.com/vistarmedia/geom/geos-go/handle"
 )
 
 var (
-	ctx     = geomcontext.NewContext()
-	geoFact = ctx.Factory()
+	hp      = handle.NewPooledHandleProvider()
+	geoFact = geom.NewFactory(hp)
 	dec     = NewDecoder(geoFact)
-	wkt     = ctx.WKTEncoder()
+	encoder = NewEncoder()
+	wkt     = wktenc.NewEncoder(hp)
+	wktDec  = wktenc.NewDecoder(hp, geoFact)
 )
 
 func decode(s string) (*geom.Geometry, error) {
 	return dec.Decode([]byte(s))
 }
 
+// Asserts the geometry is structurally equal to the WKT
+func assertEqualsExact(t *testing.T, g *geom.Geometry, expWKT string) {
+	t.Helper()
+	exp, err := wktDec.Decode(expWKT)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if eq, err := g.EqualsExact(exp, 0); err != nil {
+		t.Fatal(err)
+	} else if !eq {
+		t.Fatalf("expected '%s', got '%s'", expWKT, wkt.Encode(g))
+	}
+}
+
 func TestDecodeInvalidType(t *testing.T) {
 	_, err := decode(`{"type":"party"}`)
 
@@ -104,11 +120,7 @@ func TestDecodePolygonNoHoles(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
-	enc := wkt.Encode(g)
-	exp := `POLYGON ((1.0000000000000000 2.0000000000000000, 3.0000000000000000 4.0000000000000000, 5.0000000000000000 6.0000000000000000, 1.0000000000000000 2.0000000000000000))`
-	if enc != exp {
-		t.Fatalf("expected '%s', got '%s'", exp, enc)
-	}
+	assertEqualsExact(t, g, "POLYGON ((1 2, 3 4, 5 6, 1 2))")
 }
 
 func TestDecodeMultipolygon(t *testing.T) {
@@ -120,8 +132,37 @@ func TestDecodeMultipolygon(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	enc := wkt.Encode(g)
-	if !strings.HasPrefix(enc, "MULTIPOLYGON (") {
-		t.Fatalf("Unexpected WKT: %s", enc)
+	assertEqualsExact(t, g,
+		"MULTIPOLYGON (((1 2, 4 5, 7 8, 1 2), (-1 -2, -4 -5, -7 -8, -1 -2)))")
+}
+
+func TestEncodeRoundTrip(t *testing.T) {
+	for _, s := range []string{
+		`{"type":"Point","coordinates":[1,2]}`,
+		`{"type":"Polygon","coordinates":[]}`,
+		`{"type":"Polygon","coordinates":[[[0,0],[4,0],[4,4],[0,0]],[[1,1],[2,1],[2,2],[1,1]]]}`,
+		`{"type":"MultiPolygon","coordinates":[[[[1,2],[4,5],[7,8],[1,2]]],[[[-1,-2],[-4,-5],[-7,-8],[-1,-2]]]]}`,
+	} {
+		g, err := decode(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, err := encoder.Encode(g)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(b) != s {
+			t.Errorf("Expected %s, got %s", s, b)
+		}
+	}
+}
+
+func TestEncodeUnsupported(t *testing.T) {
+	line, err := geoFact.NewLineString([]geom.Coord{{0, 0}, {1, 1}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := encoder.Encode(line.Geometry); err == nil {
+		t.Error("Expected an error encoding a LineString")
 	}
 }
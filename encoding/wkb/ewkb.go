@@ -0,0 +1,49 @@
+package wkb
+
+import (
+	"encoding/binary"
+
+	"github.com/vistarmedia/geom"
+	"github.com/vistarmedia/geom/encoding/ewkb"
+)
+
+// Decodes WKB and EWKB in Go with the ewkb package, only calling in to GEOS
+// to build the final geometry. A non-zero SRID in the input overrides the
+// factory's.
+type GoDecoder struct {
+	factory geom.Factory
+}
+
+func NewGoDecoder(fact geom.Factory) *GoDecoder {
+	return &GoDecoder{fact}
+}
+
+func (d *GoDecoder) Decode(wkb []byte) (*geom.Geometry, error) {
+	s, srid, err := ewkb.Unmarshal(wkb)
+	if err != nil {
+		return nil, err
+	}
+	f := d.factory
+	if srid != 0 {
+		f = f.With(geom.WithSRID(srid))
+	}
+	return f.FromShape(s)
+}
+
+// Encodes geometries in Go with the ewkb package. Geometries with an SRID are
+// written as EWKB.
+type GoEncoder struct {
+	order binary.ByteOrder
+}
+
+func NewGoEncoder(order binary.ByteOrder) *GoEncoder {
+	return &GoEncoder{order}
+}
+
+func (e *GoEncoder) Encode(g *geom.Geometry) ([]byte, error) {
+	s, err := g.Shape()
+	if err != nil {
+		return nil, err
+	}
+	return ewkb.Marshal(s, g.SRID(), e.order)
+}
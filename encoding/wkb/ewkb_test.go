@@ -0,0 +1,81 @@
+package wkb
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/vistarmedia/geom"
+	"github.com/vistarmedia/geom/encoding/ewkb"
+	"github.com/vistarmedia/geom/geos-go/handle"
+	"github.com/vistarmedia/geom/shape"
+)
+
+func TestGoCodecMatchesGeos(t *testing.T) {
+	hp := handle.NewPooledHandleProvider()
+	fact := geom.NewFactory(hp)
+	poly, err := fact.NewPolygon(
+		[]geom.Coord{{0, 0}, {4, 0}, {4, 4}, {0, 4}, {0, 0}},
+		[]geom.Coord{{1, 1}, {2, 1}, {2, 2}, {1, 1}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	mp, err := fact.NewMultipolygon(poly)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	goWKB, err := NewGoEncoder(binary.LittleEndian).Encode(mp.Geometry)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if geosWKB := NewEncoder(hp).Encode(mp); string(goWKB) != string(geosWKB) {
+		t.Errorf("Expected %x, got %x", geosWKB, goWKB)
+	}
+
+	decoded, err := NewGoDecoder(fact).Decode(goWKB)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if eq, err := decoded.EqualsExact(mp, 0); err != nil {
+		t.Fatal(err)
+	} else if !eq || decoded.Type() != geom.MULTIPOLYGON {
+		t.Error("Expected decoded geometry to equal the original")
+	}
+}
+
+func TestGoCodecSRID(t *testing.T) {
+	fact := geom.NewFactory(handle.NewPooledHandleProvider())
+	coll := shape.Collection{
+		shape.MultiPoint{{X: 1, Y: 2}},
+		shape.MultiLineString{{{X: 0, Y: 0}, {X: 1, Y: 1}}},
+		shape.EmptyPoint(),
+		shape.Polygon{},
+	}
+	b, err := ewkb.Marshal(coll, 4326, binary.LittleEndian)
+	if err != nil {
+		t.Fatal(err)
+	}
+	g, err := NewGoDecoder(fact.With(geom.WithSRID(3857))).Decode(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if g.SRID() != 4326 {
+		t.Errorf("Expected SRID 4326, got %d", g.SRID())
+	}
+	gs, err := g.Geometries()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if gs[0].Type() != geom.MULTIPOINT || gs[1].Type() != geom.MULTILINESTRING {
+		t.Errorf("Expected multi types to be preserved, got %d and %d",
+			gs[0].Type(), gs[1].Type())
+	}
+
+	back, err := NewGoEncoder(binary.LittleEndian).Encode(g)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != string(back) {
+		t.Errorf("Expected %x, got %x", b, back)
+	}
+}
@@ -1,4 +1,5 @@
-// Well Known Binary encoding/decoding. Assumes machine endianness.
+// Well Known Binary encoding/decoding. Writes machine endianness unless
+// configured otherwise.
 package wkb
 
 import (
@@ -14,11 +15,35 @@ type Encodeable interface {
 }
 
 type Encoder struct {
-	hp handle.GeosHandleProvider
+	hp   handle.GeosHandleProvider
+	opts []EncoderOption
 }
 
-func NewEncoder(hp handle.GeosHandleProvider) *Encoder {
-	return &Encoder{hp}
+// Configures the WKB writer of an Encoder
+type EncoderOption func(*geos.Handle, *geos.WKBWriter)
+
+func ByteOrder(order geos.WKBByteOrder) EncoderOption {
+	return func(h *geos.Handle, w *geos.WKBWriter) {
+		w.SetByteOrder(h, order)
+	}
+}
+
+// Writes each geometry's SRID, producing EWKB
+func IncludeSRID(include bool) EncoderOption {
+	return func(h *geos.Handle, w *geos.WKBWriter) {
+		w.SetIncludeSRID(h, include)
+	}
+}
+
+// Writes Z coordinates when 3
+func OutputDimension(dim int) EncoderOption {
+	return func(h *geos.Handle, w *geos.WKBWriter) {
+		w.SetOutputDimension(h, dim)
+	}
+}
+
+func NewEncoder(hp handle.GeosHandleProvider, opts ...EncoderOption) *Encoder {
+	return &Encoder{hp, opts}
 }
 
 func (e *Encoder) Encode(g Encodeable) []byte {
@@ -29,6 +54,9 @@ func (e *Encoder) Encode(g Encodeable) []byte {
 	// time.
 	writer := geos.NewWKBWriter(h)
 	defer writer.Destroy(h)
+	for _, opt := range e.opts {
+		opt(h, writer)
+	}
 	wkb := writer.Write(h, g.UnsafeToGeos())
 	runtime.KeepAlive(g)
 	return wkb
@@ -55,5 +83,5 @@ func (d *Decoder) Decode(wkb []byte) (*geom.Geometry, error) {
 	if err != nil {
 		return nil, err
 	}
-	return d.factory.FromGeos(geom), nil
+	return d.factory.FromGeosWithOptions(geom)
 }
@@ -18,18 +18,43 @@ type Encoder struct {
 	hp     handle.GeosHandleProvider
 }
 
-func NewEncoder(hp handle.GeosHandleProvider) *Encoder {
+// Configures the WKT writer of an Encoder
+type EncoderOption func(*geos.Handle, *geos.WKTWriter)
+
+// Trims trailing zeros from coordinates
+func Trim(trim bool) EncoderOption {
+	return func(h *geos.Handle, w *geos.WKTWriter) {
+		w.SetTrim(h, trim)
+	}
+}
+
+// Number of decimal places written. Negative values use full precision.
+func RoundingPrecision(precision int) EncoderOption {
+	return func(h *geos.Handle, w *geos.WKTWriter) {
+		w.SetRoundingPrecision(h, precision)
+	}
+}
+
+// Writes Z coordinates when 3
+func OutputDimension(dim int) EncoderOption {
+	return func(h *geos.Handle, w *geos.WKTWriter) {
+		w.SetOutputDimension(h, dim)
+	}
+}
+
+func NewEncoder(hp handle.GeosHandleProvider, opts ...EncoderOption) *Encoder {
 	h := hp.Get()
 	writer := geos.NewWKTWriter(h)
+	for _, opt := range opts {
+		opt(h, writer)
+	}
 	hp.Put(h)
 	encoder := &Encoder{
 		hp:     hp,
 		writer: writer,
 	}
 	runtime.SetFinalizer(encoder, func(encoder1 *Encoder) {
-		h := encoder1.hp.Get()
-		encoder1.writer.Destroy(h)
-		encoder1.hp.Put(h)
+		handle.Finalize(encoder1.writer.Destroy)
 	})
 	return encoder
 }
@@ -58,9 +83,7 @@ func NewDecoder(hp handle.GeosHandleProvider, fact geom.Factory) *Decoder {
 		factory: fact,
 	}
 	runtime.SetFinalizer(decoder, func(decoder1 *Decoder) {
-		h := decoder1.hp.Get()
-		decoder1.reader.Destroy(h)
-		decoder1.hp.Put(h)
+		handle.Finalize(decoder1.reader.Destroy)
 	})
 	return decoder
 }
@@ -72,5 +95,5 @@ func (d *Decoder) Decode(wkt string) (*geom.Geometry, error) {
 	if err != nil {
 		return nil, err
 	}
-	return d.factory.FromGeos(geom), nil
+	return d.factory.FromGeosWithOptions(geom)
 }
@@ -0,0 +1,183 @@
+package geom
+
+import (
+	"github.com/vistarmedia/geom/engine"
+	"github.com/vistarmedia/geom/geos-go/handle"
+	"github.com/vistarmedia/geom/shape"
+)
+
+// Makes GEOS the engine behind engine.Default in programs linking geom
+func init() {
+	engine.SetDefault(NewGeosEngine(NewFactory(handle.NewPooledHandleProvider())))
+}
+
+// Engine running every operation through GEOS. Shapes are converted with the
+// factory, so its precision applies to inputs.
+type GeosEngine struct {
+	factory Factory
+}
+
+var _ engine.Engine = GeosEngine{}
+
+func NewGeosEngine(f Factory) GeosEngine {
+	return GeosEngine{f}
+}
+
+func (e GeosEngine) Area(s shape.Shape) (float64, error) {
+	g, err := e.factory.FromShape(s)
+	if err != nil {
+		return 0, err
+	}
+	defer g.Close()
+	return g.Area(), nil
+}
+
+func (e GeosEngine) Bounds(s shape.Shape) (min, max shape.Coord, err error) {
+	g, err := e.factory.FromShape(s)
+	if err != nil {
+		return
+	}
+	defer g.Close()
+	gmin, gmax, err := g.Bounds()
+	if err == ErrEmptyGeometry {
+		err = engine.ErrEmptyShape
+	}
+	return shape.Coord(gmin), shape.Coord(gmax), err
+}
+
+func (e GeosEngine) Envelope(s shape.Shape) (shape.Shape, error) {
+	return e.unary(s, (*Geometry).Envelope)
+}
+
+func (e GeosEngine) Centroid(s shape.Shape) (shape.Point, error) {
+	c, err := e.unary(s, (*Geometry).Centroid)
+	if err != nil {
+		return shape.Point{}, err
+	}
+	return c.(shape.Point), nil
+}
+
+func (e GeosEngine) Buffer(
+	s shape.Shape, width float64, quadsegs int) (shape.Shape, error) {
+
+	return e.unary(s, func(g *Geometry) (*Geometry, error) {
+		return g.Buffer(width, quadsegs)
+	})
+}
+
+func (e GeosEngine) Intersection(a, b shape.Shape) (shape.Shape, error) {
+	return e.binary(a, b, func(g, o *Geometry) (*Geometry, error) {
+		return g.Intersection(o)
+	})
+}
+
+func (e GeosEngine) Union(a, b shape.Shape) (shape.Shape, error) {
+	return e.binary(a, b, func(g, o *Geometry) (*Geometry, error) {
+		return g.Union(o)
+	})
+}
+
+func (e GeosEngine) Intersects(a, b shape.Shape) (bool, error) {
+	return e.predicate(a, b, func(g, o *Geometry) (bool, error) {
+		return g.Intersects(o)
+	})
+}
+
+func (e GeosEngine) Disjoint(a, b shape.Shape) (bool, error) {
+	return e.predicate(a, b, func(g, o *Geometry) (bool, error) {
+		return g.Disjoint(o)
+	})
+}
+
+func (e GeosEngine) Contains(a, b shape.Shape) (bool, error) {
+	return e.predicate(a, b, func(g, o *Geometry) (bool, error) {
+		return g.Contains(o)
+	})
+}
+
+func (e GeosEngine) Within(a, b shape.Shape) (bool, error) {
+	return e.predicate(a, b, func(g, o *Geometry) (bool, error) {
+		return g.Within(o)
+	})
+}
+
+func (e GeosEngine) Covers(a, b shape.Shape) (bool, error) {
+	return e.predicate(a, b, func(g, o *Geometry) (bool, error) {
+		return g.Covers(o)
+	})
+}
+
+func (e GeosEngine) CoveredBy(a, b shape.Shape) (bool, error) {
+	return e.predicate(a, b, func(g, o *Geometry) (bool, error) {
+		return g.CoveredBy(o)
+	})
+}
+
+func (e GeosEngine) Touches(a, b shape.Shape) (bool, error) {
+	return e.predicate(a, b, func(g, o *Geometry) (bool, error) {
+		return g.Touches(o)
+	})
+}
+
+func (e GeosEngine) Overlaps(a, b shape.Shape) (bool, error) {
+	return e.predicate(a, b, func(g, o *Geometry) (bool, error) {
+		return g.Overlaps(o)
+	})
+}
+
+func (e GeosEngine) Crosses(a, b shape.Shape) (bool, error) {
+	return e.predicate(a, b, func(g, o *Geometry) (bool, error) {
+		return g.Crosses(o)
+	})
+}
+
+func (e GeosEngine) Equals(a, b shape.Shape) (bool, error) {
+	return e.predicate(a, b, func(g, o *Geometry) (bool, error) {
+		return g.Equals(o)
+	})
+}
+
+func (e GeosEngine) unary(
+	s shape.Shape, op func(*Geometry) (*Geometry, error)) (shape.Shape, error) {
+
+	g, err := e.factory.FromShape(s)
+	if err != nil {
+		return nil, err
+	}
+	defer g.Close()
+	res, err := op(g)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Close()
+	return res.Shape()
+}
+
+func (e GeosEngine) binary(a, b shape.Shape,
+	op func(g, o *Geometry) (*Geometry, error)) (shape.Shape, error) {
+
+	return e.unary(a, func(g *Geometry) (*Geometry, error) {
+		o, err := e.factory.FromShape(b)
+		if err != nil {
+			return nil, err
+		}
+		defer o.Close()
+		return op(g, o)
+	})
+}
+
+func (e GeosEngine) predicate(a, b shape.Shape,
+	op func(g, o *Geometry) (bool, error)) (bool, error) {
+
+	g, err := e.factory.FromShape(a)
+	if err != nil {
+		return false, err
+	}
+	defer g.Close()
+	o, err := e.factory.FromShape(b)
+	if err != nil {
+		return false, err
+	}
+	defer o.Close()
+	return op(g, o)
+}
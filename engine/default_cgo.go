@@ -0,0 +1,18 @@
+//go:build cgo
+
+package engine
+
+var defaultEngine Engine = Planar{}
+
+// Engine used when a program doesn't pick one. With cgo, this is the GEOS
+// engine once the geom package is linked, and Planar otherwise.
+func Default() Engine {
+	return defaultEngine
+}
+
+// Replaces the engine returned by Default. Not safe to call concurrently with
+// Default, so call it from an init function. geom registers its GEOS engine
+// this way.
+func SetDefault(e Engine) {
+	defaultEngine = e
+}
@@ -0,0 +1,9 @@
+//go:build !cgo
+
+package engine
+
+// Engine used when a program doesn't pick one. Without cgo this is always
+// Planar.
+func Default() Engine {
+	return Planar{}
+}
@@ -0,0 +1,51 @@
+// Backends for geometry operations on plain Go shapes. The GEOS backend is
+// geom.GeosEngine. Planar is written in Go and needs neither cgo nor libgeos,
+// but only supports the tractable subset of operations, returning
+// ErrUnsupported for the rest. This package and shape are the entry points
+// for programs built without cgo.
+//
+// Default picks an engine by build: GEOS when built with cgo and linking
+// geom, and Planar otherwise.
+package engine
+
+import (
+	"errors"
+
+	"github.com/vistarmedia/geom/shape"
+)
+
+var (
+	ErrUnsupported = errors.New("Unsupported by engine")
+	ErrEmptyShape  = errors.New("Empty shape")
+)
+
+// Operations matching those of geom.Geometry. See the OGC Simple Feature
+// Specification for predicate semantics.
+type Engine interface {
+	Area(s shape.Shape) (float64, error)
+
+	// Minimum and maximum coordinates of s. Errors with ErrEmptyShape when s
+	// is empty.
+	Bounds(s shape.Shape) (min, max shape.Coord, err error)
+
+	// Bounding box of s as a Polygon, or a Point or LineString when the box is
+	// degenerate
+	Envelope(s shape.Shape) (shape.Shape, error)
+
+	Centroid(s shape.Shape) (shape.Point, error)
+
+	Buffer(s shape.Shape, width float64, quadsegs int) (shape.Shape, error)
+	Intersection(a, b shape.Shape) (shape.Shape, error)
+	Union(a, b shape.Shape) (shape.Shape, error)
+
+	Intersects(a, b shape.Shape) (bool, error)
+	Disjoint(a, b shape.Shape) (bool, error)
+	Contains(a, b shape.Shape) (bool, error)
+	Within(a, b shape.Shape) (bool, error)
+	Covers(a, b shape.Shape) (bool, error)
+	CoveredBy(a, b shape.Shape) (bool, error)
+	Touches(a, b shape.Shape) (bool, error)
+	Overlaps(a, b shape.Shape) (bool, error)
+	Crosses(a, b shape.Shape) (bool, error)
+	Equals(a, b shape.Shape) (bool, error)
+}
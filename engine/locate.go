@@ -0,0 +1,139 @@
+package engine
+
+import (
+	"math"
+	"math/big"
+
+	"github.com/vistarmedia/geom/shape"
+)
+
+// Location of a coordinate relative to a shape
+type Location int
+
+const (
+	EXTERIOR Location = iota
+	BOUNDARY
+	INTERIOR
+)
+
+// Location of c relative to a Polygon or MultiPolygon, treating every ring of
+// every polygon as one area, as GEOS's indexed point-in-area locator does.
+// Only valid polygonal shapes give meaningful results.
+func Locate(c shape.Coord, polys []shape.Polygon) Location {
+	var counter rayCrossingCounter
+	counter.p = c
+	for _, poly := range polys {
+		for _, ring := range poly {
+			for i := 1; i < len(ring); i++ {
+				if counter.countSegment(ring[i-1], ring[i]) {
+					return BOUNDARY
+				}
+			}
+		}
+	}
+	return counter.location()
+}
+
+// Counts crossings of a ray extending right from p, following GEOS's
+// RayCrossingCounter so that points on the boundary are found exactly.
+type rayCrossingCounter struct {
+	p         shape.Coord
+	crossings int
+}
+
+// Returns true if p lies on the segment from p1 to p2
+func (rc *rayCrossingCounter) countSegment(p1, p2 shape.Coord) bool {
+	p := rc.p
+	if p1.X < p.X && p2.X < p.X {
+		return false
+	}
+	if p == p2 {
+		return true
+	}
+	if p1.Y == p.Y && p2.Y == p.Y {
+		minX, maxX := p1.X, p2.X
+		if minX > maxX {
+			minX, maxX = maxX, minX
+		}
+		return p.X >= minX && p.X <= maxX
+	}
+	if (p1.Y > p.Y && p2.Y <= p.Y) || (p2.Y > p.Y && p1.Y <= p.Y) {
+		sign := orientation(p1, p2, p)
+		if sign == 0 {
+			return true
+		}
+		if p2.Y < p1.Y {
+			sign = -sign
+		}
+		if sign > 0 {
+			rc.crossings++
+		}
+	}
+	return false
+}
+
+func (rc *rayCrossingCounter) location() Location {
+	if rc.crossings%2 == 1 {
+		return INTERIOR
+	}
+	return EXTERIOR
+}
+
+// Sign of the turn from p1 to p2 to q: 1 for counter-clockwise, -1 for
+// clockwise and 0 for collinear. Uses floating point when its error bound
+// allows, otherwise exact rational arithmetic.
+func orientation(p1, p2, q shape.Coord) int {
+	detLeft := (p1.X - q.X) * (p2.Y - q.Y)
+	detRight := (p1.Y - q.Y) * (p2.X - q.X)
+	det := detLeft - detRight
+
+	var detSum float64
+	switch {
+	case detLeft > 0:
+		if detRight <= 0 {
+			return sign(det)
+		}
+		detSum = detLeft + detRight
+	case detLeft < 0:
+		if detRight >= 0 {
+			return sign(det)
+		}
+		detSum = -detLeft - detRight
+	default:
+		return sign(det)
+	}
+	if math.IsInf(detSum, 0) {
+		return sign(det)
+	}
+	if errBound := safeEpsilon * detSum; det >= errBound || -det >= errBound {
+		return sign(det)
+	}
+	return exactOrientation(p1, p2, q)
+}
+
+// Relative error bound of the floating point determinant, as in GEOS
+const safeEpsilon = 1e-15
+
+func exactOrientation(p1, p2, q shape.Coord) int {
+	rat := func(f float64) *big.Rat {
+		return new(big.Rat).SetFloat64(f)
+	}
+	dx1 := new(big.Rat).Sub(rat(p2.X), rat(p1.X))
+	dy1 := new(big.Rat).Sub(rat(p2.Y), rat(p1.Y))
+	dx2 := new(big.Rat).Sub(rat(q.X), rat(p2.X))
+	dy2 := new(big.Rat).Sub(rat(q.Y), rat(p2.Y))
+	left := new(big.Rat).Mul(dx1, dy2)
+	right := new(big.Rat).Mul(dy1, dx2)
+	return left.Cmp(right)
+}
+
+func sign(f float64) int {
+	switch {
+	case f > 0:
+		return 1
+	case f < 0:
+		return -1
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,374 @@
+package engine
+
+import (
+	"math"
+
+	"github.com/vistarmedia/geom/shape"
+)
+
+// Pure Go engine. Supports area, bounds, envelope and centroid of any shape,
+// and predicates between points and polygons, including their Multi types.
+// Buffers, overlays and predicates involving lines or collections return
+// ErrUnsupported.
+type Planar struct{}
+
+var _ Engine = Planar{}
+
+func (Planar) Area(s shape.Shape) (float64, error) {
+	switch s := s.(type) {
+	case shape.Point, shape.LineString, shape.LinearRing, shape.MultiPoint,
+		shape.MultiLineString:
+		return 0, nil
+	case shape.Polygon:
+		return polygonArea(s), nil
+	case shape.MultiPolygon:
+		area := 0.0
+		for _, p := range s {
+			area += polygonArea(p)
+		}
+		return area, nil
+	case shape.Collection:
+		area := 0.0
+		for _, member := range s {
+			memberArea, err := Planar{}.Area(member)
+			if err != nil {
+				return 0, err
+			}
+			area += memberArea
+		}
+		return area, nil
+	default:
+		return 0, shape.ErrUnsupportedShape
+	}
+}
+
+func (Planar) Bounds(s shape.Shape) (min, max shape.Coord, err error) {
+	min, max, ok := s.Bounds()
+	if !ok {
+		err = ErrEmptyShape
+	}
+	return
+}
+
+func (Planar) Envelope(s shape.Shape) (shape.Shape, error) {
+	min, max, ok := s.Bounds()
+	switch {
+	case !ok:
+		return shape.EmptyPoint(), nil
+	case min == max:
+		return shape.Point(min), nil
+	case min.X == max.X || min.Y == max.Y:
+		return shape.LineString{min, max}, nil
+	default:
+		return shape.Polygon{{
+			min, {X: max.X, Y: min.Y}, max, {X: min.X, Y: max.Y}, min,
+		}}, nil
+	}
+}
+
+// Like GEOS, only the highest dimension parts contribute. Polygons with no
+// area fall back to their rings, and lines with no length to their points.
+func (Planar) Centroid(s shape.Shape) (shape.Point, error) {
+	var c centroid
+	if err := c.add(s); err != nil {
+		return shape.Point{}, err
+	}
+	return c.result(), nil
+}
+
+func (Planar) Buffer(shape.Shape, float64, int) (shape.Shape, error) {
+	return nil, ErrUnsupported
+}
+
+func (Planar) Intersection(a, b shape.Shape) (shape.Shape, error) {
+	return nil, ErrUnsupported
+}
+
+func (Planar) Union(a, b shape.Shape) (shape.Shape, error) {
+	return nil, ErrUnsupported
+}
+
+func (Planar) Intersects(a, b shape.Shape) (bool, error) {
+	r, err := relatePoints(a, b)
+	return r.some(INTERIOR) || r.some(BOUNDARY), err
+}
+
+func (Planar) Disjoint(a, b shape.Shape) (bool, error) {
+	intersects, err := Planar{}.Intersects(a, b)
+	return err == nil && !intersects, err
+}
+
+func (Planar) Contains(a, b shape.Shape) (bool, error) {
+	r, err := relatePoints(a, b)
+	if err != nil || r.swapped {
+		return false, err
+	}
+	return r.none(EXTERIOR) && r.some(INTERIOR), nil
+}
+
+func (Planar) Within(a, b shape.Shape) (bool, error) {
+	return Planar{}.Contains(b, a)
+}
+
+func (Planar) Covers(a, b shape.Shape) (bool, error) {
+	r, err := relatePoints(a, b)
+	if err != nil || r.swapped {
+		return false, err
+	}
+	return len(r.locs) > 0 && r.none(EXTERIOR), nil
+}
+
+func (Planar) CoveredBy(a, b shape.Shape) (bool, error) {
+	return Planar{}.Covers(b, a)
+}
+
+func (Planar) Touches(a, b shape.Shape) (bool, error) {
+	r, err := relatePoints(a, b)
+	return r.none(INTERIOR) && r.some(BOUNDARY), err
+}
+
+func (Planar) Overlaps(a, b shape.Shape) (bool, error) {
+	r, err := relatePoints(a, b)
+	if err != nil || r.area {
+		// Shapes of different dimensions never overlap
+		return false, err
+	}
+	covered, err := Planar{}.Covers(b, a)
+	return r.some(INTERIOR) && r.some(EXTERIOR) && !covered, err
+}
+
+func (Planar) Crosses(a, b shape.Shape) (bool, error) {
+	r, err := relatePoints(a, b)
+	if err != nil || !r.area {
+		// Points never cross points
+		return false, err
+	}
+	return r.some(INTERIOR) && r.some(EXTERIOR), nil
+}
+
+func (Planar) Equals(a, b shape.Shape) (bool, error) {
+	r, err := relatePoints(a, b)
+	if err != nil || r.area {
+		return false, err
+	}
+	covered, err := Planar{}.Covers(b, a)
+	return len(r.locs) > 0 && r.none(EXTERIOR) && covered, err
+}
+
+// Locations of the points of one shape relative to the other, which must be
+// points or polygons
+type pointRelation struct {
+	locs []Location
+
+	// Whether the points were located in an area rather than other points
+	area bool
+
+	// Whether the points came from the first shape rather than the second
+	swapped bool
+}
+
+func relatePoints(a, b shape.Shape) (r pointRelation, err error) {
+	pts, ok := points(b)
+	if !ok {
+		if pts, ok = points(a); !ok {
+			return r, ErrUnsupported
+		}
+		a, r.swapped = b, true
+	}
+
+	if polys, ok := polygons(a); ok {
+		r.area = true
+		for _, p := range pts {
+			r.locs = append(r.locs, Locate(p, polys))
+		}
+		return r, nil
+	}
+
+	others, ok := points(a)
+	if !ok {
+		return r, ErrUnsupported
+	}
+	for _, p := range pts {
+		loc := EXTERIOR
+		for _, o := range others {
+			if p == o {
+				loc = INTERIOR
+				break
+			}
+		}
+		r.locs = append(r.locs, loc)
+	}
+	return r, nil
+}
+
+func (r pointRelation) some(loc Location) bool {
+	for _, l := range r.locs {
+		if l == loc {
+			return true
+		}
+	}
+	return false
+}
+
+func (r pointRelation) none(loc Location) bool {
+	return !r.some(loc)
+}
+
+// Coordinates of a Point or MultiPoint, skipping empty points
+func points(s shape.Shape) ([]shape.Coord, bool) {
+	switch s := s.(type) {
+	case shape.Point:
+		if s.IsEmpty() {
+			return nil, true
+		}
+		return []shape.Coord{shape.Coord(s)}, true
+	case shape.MultiPoint:
+		coords := make([]shape.Coord, 0, len(s))
+		for _, p := range s {
+			if !p.IsEmpty() {
+				coords = append(coords, shape.Coord(p))
+			}
+		}
+		return coords, true
+	default:
+		return nil, false
+	}
+}
+
+func polygons(s shape.Shape) ([]shape.Polygon, bool) {
+	switch s := s.(type) {
+	case shape.Polygon:
+		return []shape.Polygon{s}, true
+	case shape.MultiPolygon:
+		return s, true
+	default:
+		return nil, false
+	}
+}
+
+func polygonArea(p shape.Polygon) float64 {
+	area := 0.0
+	for i, ring := range p {
+		ringArea := math.Abs(signedArea(ring))
+		if i == 0 {
+			area += ringArea
+		} else {
+			area -= ringArea
+		}
+	}
+	return area
+}
+
+// Shoelace formula, positive for counter-clockwise rings. Coordinates are
+// taken relative to the first to limit rounding error.
+func signedArea(ring []shape.Coord) float64 {
+	if len(ring) < 3 {
+		return 0
+	}
+	base := ring[0]
+	sum := 0.0
+	for i := 1; i < len(ring)-1; i++ {
+		x0, y0 := ring[i].X-base.X, ring[i].Y-base.Y
+		x1, y1 := ring[i+1].X-base.X, ring[i+1].Y-base.Y
+		sum += x0*y1 - x1*y0
+	}
+	return sum / 2
+}
+
+// Running sums for each dimension, as in GEOS's Centroid
+type centroid struct {
+	areaSum, areaX, areaY float64
+	length, lineX, lineY  float64
+	numPoints             int
+	pointX, pointY        float64
+}
+
+func (c *centroid) add(s shape.Shape) error {
+	switch s := s.(type) {
+	case shape.Point:
+		if !s.IsEmpty() {
+			c.numPoints++
+			c.pointX += s.X
+			c.pointY += s.Y
+		}
+	case shape.LineString:
+		c.addLine(s)
+	case shape.LinearRing:
+		c.addLine(s)
+	case shape.Polygon:
+		c.addPolygon(s)
+	case shape.MultiPoint:
+		for _, p := range s {
+			c.add(p)
+		}
+	case shape.MultiLineString:
+		for _, ls := range s {
+			c.addLine(ls)
+		}
+	case shape.MultiPolygon:
+		for _, p := range s {
+			c.addPolygon(p)
+		}
+	case shape.Collection:
+		for _, member := range s {
+			if err := c.add(member); err != nil {
+				return err
+			}
+		}
+	default:
+		return shape.ErrUnsupportedShape
+	}
+	return nil
+}
+
+func (c *centroid) addPolygon(p shape.Polygon) {
+	if len(p) == 0 || len(p[0]) == 0 {
+		return
+	}
+	base := p[0][0]
+	for i, ring := range p {
+		// Shell area counts positively and holes negatively, whichever way
+		// the rings wind
+		weight := 1.0
+		if (signedArea(ring) < 0) == (i == 0) {
+			weight = -1
+		}
+		for j := 1; j < len(ring); j++ {
+			a, b := ring[j-1], ring[j]
+			area2 := weight * ((a.X-base.X)*(b.Y-base.Y) - (b.X-base.X)*(a.Y-base.Y))
+			c.areaSum += area2
+			c.areaX += area2 * (base.X + a.X + b.X)
+			c.areaY += area2 * (base.Y + a.Y + b.Y)
+		}
+		c.addLine(ring)
+	}
+}
+
+func (c *centroid) addLine(coords []shape.Coord) {
+	lineLength := 0.0
+	for i := 1; i < len(coords); i++ {
+		a, b := coords[i-1], coords[i]
+		length := math.Hypot(b.X-a.X, b.Y-a.Y)
+		lineLength += length
+		c.lineX += length * (a.X + b.X) / 2
+		c.lineY += length * (a.Y + b.Y) / 2
+	}
+	c.length += lineLength
+	if lineLength == 0 && len(coords) > 0 {
+		c.add(shape.Point(coords[0]))
+	}
+}
+
+func (c *centroid) result() shape.Point {
+	switch {
+	case c.areaSum != 0:
+		return shape.Point{X: c.areaX / 3 / c.areaSum, Y: c.areaY / 3 / c.areaSum}
+	case c.length > 0:
+		return shape.Point{X: c.lineX / c.length, Y: c.lineY / c.length}
+	case c.numPoints > 0:
+		n := float64(c.numPoints)
+		return shape.Point{X: c.pointX / n, Y: c.pointY / n}
+	default:
+		return shape.EmptyPoint()
+	}
+}
@@ -0,0 +1,160 @@
+package engine
+
+import (
+	"math"
+	"testing"
+
+	"github.com/vistarmedia/geom/shape"
+)
+
+var (
+	square = shape.Polygon{
+		{{X: 0, Y: 0}, {X: 4, Y: 0}, {X: 4, Y: 4}, {X: 0, Y: 4}, {X: 0, Y: 0}},
+		{{X: 1, Y: 1}, {X: 1, Y: 2}, {X: 2, Y: 2}, {X: 2, Y: 1}, {X: 1, Y: 1}},
+	}
+	planar = Planar{}
+)
+
+func TestDefaultWithoutGeom(t *testing.T) {
+	if _, ok := Default().(Planar); !ok {
+		t.Errorf("Expected Planar, got %T", Default())
+	}
+}
+
+func TestArea(t *testing.T) {
+	area, err := planar.Area(shape.Collection{
+		square,
+		shape.MultiPolygon{{{
+			{X: 10, Y: 10}, {X: 10, Y: 11}, {X: 11, Y: 10}, {X: 10, Y: 10},
+		}}},
+		shape.Point{X: 1, Y: 1},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if area != 15.5 {
+		t.Errorf("Expected area 15.5, got %f", area)
+	}
+}
+
+func TestEnvelope(t *testing.T) {
+	envs := []struct {
+		s   shape.Shape
+		env shape.Shape
+	}{
+		{square, shape.Polygon{square[0]}},
+		{shape.MultiPoint{{X: 1, Y: 1}, {X: 1, Y: 3}},
+			shape.LineString{{X: 1, Y: 1}, {X: 1, Y: 3}}},
+		{shape.LineString{{X: 2, Y: 2}, {X: 2, Y: 2}}, shape.Point{X: 2, Y: 2}},
+		{shape.Collection{}, shape.EmptyPoint()},
+	}
+	for _, tt := range envs {
+		env, err := planar.Envelope(tt.s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !shape.Equal(env, tt.env) {
+			t.Errorf("Expected %v, got %v", tt.env, env)
+		}
+	}
+
+	if _, _, err := planar.Bounds(shape.Polygon{}); err != ErrEmptyShape {
+		t.Errorf("Expected ErrEmptyShape, got %v", err)
+	}
+}
+
+func TestCentroid(t *testing.T) {
+	centroids := []struct {
+		s shape.Shape
+		c shape.Point
+	}{
+		// The hole pulls the centroid away from (1.5, 1.5)
+		{square, shape.Point{X: 2.0333333333333, Y: 2.0333333333333}},
+		{shape.Collection{square, shape.Point{X: 100, Y: 100}},
+			shape.Point{X: 2.0333333333333, Y: 2.0333333333333}},
+		{shape.LineString{{X: 0, Y: 0}, {X: 2, Y: 0}, {X: 2, Y: 1}},
+			shape.Point{X: 4.0 / 3, Y: 1.0 / 6}},
+		{shape.MultiPoint{{X: 0, Y: 0}, {X: 2, Y: 4}}, shape.Point{X: 1, Y: 2}},
+		{shape.Polygon{{{X: 0, Y: 0}, {X: 2, Y: 0}, {X: 0, Y: 0}}},
+			shape.Point{X: 1, Y: 0}},
+	}
+	for _, tt := range centroids {
+		c, err := planar.Centroid(tt.s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if math.Abs(c.X-tt.c.X) > 1e-9 || math.Abs(c.Y-tt.c.Y) > 1e-9 {
+			t.Errorf("Expected %v, got %v", tt.c, c)
+		}
+	}
+
+	if c, _ := planar.Centroid(shape.Polygon{}); !c.IsEmpty() {
+		t.Errorf("Expected empty centroid, got %v", c)
+	}
+}
+
+func TestPointPredicates(t *testing.T) {
+	inside := shape.Point{X: 3, Y: 3}
+	edge := shape.Point{X: 4, Y: 2}
+	hole := shape.Point{X: 1.5, Y: 1.5}
+	holeEdge := shape.Point{X: 1, Y: 1.5}
+
+	checks := []struct {
+		name string
+		pred func(a, b shape.Shape) (bool, error)
+		a, b shape.Shape
+		want bool
+	}{
+		{"contains inside", planar.Contains, square, inside, true},
+		{"contains edge", planar.Contains, square, edge, false},
+		{"contains hole", planar.Contains, square, hole, false},
+		{"covers edge", planar.Covers, square, edge, true},
+		{"covers hole edge", planar.Covers, square, holeEdge, true},
+		{"covers hole", planar.Covers, square, hole, false},
+		{"within", planar.Within, inside, square, true},
+		{"covered by", planar.CoveredBy, edge, square, true},
+		{"intersects", planar.Intersects, edge, square, true},
+		{"disjoint", planar.Disjoint, square, hole, true},
+		{"touches", planar.Touches, square, holeEdge, true},
+		{"touches inside", planar.Touches, square, inside, false},
+		{"contains multipoint", planar.Contains, square,
+			shape.MultiPoint{inside, edge}, true},
+		{"contains boundary only", planar.Contains, square,
+			shape.MultiPoint{edge, holeEdge}, false},
+		{"crosses", planar.Crosses, shape.MultiPoint{inside, hole}, square, true},
+		{"overlaps area", planar.Overlaps, square, inside, false},
+		{"overlaps points", planar.Overlaps,
+			shape.MultiPoint{{X: 0, Y: 0}, {X: 1, Y: 1}},
+			shape.MultiPoint{{X: 1, Y: 1}, {X: 2, Y: 2}}, true},
+		{"equals", planar.Equals,
+			shape.MultiPoint{{X: 0, Y: 0}, {X: 1, Y: 1}},
+			shape.MultiPoint{{X: 1, Y: 1}, {X: 0, Y: 0}}, true},
+	}
+	for _, check := range checks {
+		got, err := check.pred(check.a, check.b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != check.want {
+			t.Errorf("%s: expected %v, got %v", check.name, check.want, got)
+		}
+	}
+
+	if _, err := planar.Intersects(square, square); err != ErrUnsupported {
+		t.Errorf("Expected ErrUnsupported, got %v", err)
+	}
+}
+
+func TestOrientationExact(t *testing.T) {
+	// Nearly collinear points which naive floating point misjudges
+	p1 := shape.Coord{X: 0.5, Y: 0.5}
+	p2 := shape.Coord{X: 12, Y: 12}
+	q := shape.Coord{X: 24, Y: 24}
+	if o := orientation(p1, p2, q); o != 0 {
+		t.Errorf("Expected collinear, got %d", o)
+	}
+	q = shape.Coord{X: 24, Y: math.Nextafter(24, 25)}
+	if o := orientation(p1, p2, q); o != 1 {
+		t.Errorf("Expected counter-clockwise, got %d", o)
+	}
+}
@@ -0,0 +1,158 @@
+package engine
+
+import (
+	"math"
+	"sort"
+
+	"github.com/vistarmedia/geom/shape"
+)
+
+// Polygons prepared for repeated point location. Ring edges are indexed by
+// their Y range, so each lookup only tests the edges a horizontal ray through
+// the point could cross. Boundaries are found exactly, matching GEOS's
+// prepared Contains and Covers for points. Safe for concurrent use.
+type PreparedPolygon struct {
+	min, max shape.Coord
+	ok       bool
+	index    intervalIndex
+}
+
+// Prepares one or more polygons, treated as a single area like a
+// MultiPolygon. Polygons must be valid.
+func NewPreparedPolygon(polys ...shape.Polygon) *PreparedPolygon {
+	pp := &PreparedPolygon{}
+	pp.min, pp.max, pp.ok = shape.MultiPolygon(polys).Bounds()
+	var segs []segment
+	for _, poly := range polys {
+		for _, ring := range poly {
+			for i := 1; i < len(ring); i++ {
+				segs = append(segs, segment{ring[i-1], ring[i]})
+			}
+		}
+	}
+	pp.index = newIntervalIndex(segs)
+	return pp
+}
+
+func (pp *PreparedPolygon) Locate(c shape.Coord) Location {
+	if !pp.ok || c.X < pp.min.X || c.X > pp.max.X ||
+		c.Y < pp.min.Y || c.Y > pp.max.Y {
+		return EXTERIOR
+	}
+	counter := rayCrossingCounter{p: c}
+	onBoundary := pp.index.query(c.Y, func(s segment) bool {
+		return counter.countSegment(s.p1, s.p2)
+	})
+	if onBoundary {
+		return BOUNDARY
+	}
+	return counter.location()
+}
+
+// Whether c lies in the interior of the polygons
+func (pp *PreparedPolygon) Contains(c shape.Coord) bool {
+	return pp.Locate(c) == INTERIOR
+}
+
+// Whether c lies in the interior or on the boundary of the polygons
+func (pp *PreparedPolygon) Covers(c shape.Coord) bool {
+	return pp.Locate(c) != EXTERIOR
+}
+
+type segment struct {
+	p1, p2 shape.Coord
+}
+
+type interval struct {
+	min, max float64
+}
+
+// Static packed R-tree of one dimensional intervals, like GEOS's
+// SortedPackedIntervalRTree. levels[0] holds the Y range of each segment, and
+// each node of a higher level covers nodeCapacity nodes of the level below.
+type intervalIndex struct {
+	segs   []segment
+	levels [][]interval
+}
+
+const nodeCapacity = 16
+
+func newIntervalIndex(segs []segment) intervalIndex {
+	leaves := make([]interval, len(segs))
+	for i, s := range segs {
+		leaves[i] = interval{math.Min(s.p1.Y, s.p2.Y), math.Max(s.p1.Y, s.p2.Y)}
+	}
+	sort.Sort(byMidpoint{segs, leaves})
+
+	levels := [][]interval{leaves}
+	for level := leaves; len(level) > 1; {
+		next := make([]interval, (len(level)+nodeCapacity-1)/nodeCapacity)
+		for i := range next {
+			children := level[i*nodeCapacity:]
+			if len(children) > nodeCapacity {
+				children = children[:nodeCapacity]
+			}
+			next[i] = children[0]
+			for _, child := range children[1:] {
+				next[i].min = math.Min(next[i].min, child.min)
+				next[i].max = math.Max(next[i].max, child.max)
+			}
+		}
+		levels = append(levels, next)
+		level = next
+	}
+	return intervalIndex{segs, levels}
+}
+
+// Calls fn with each segment whose Y range includes y, stopping and returning
+// true as soon as fn does
+func (idx intervalIndex) query(y float64, fn func(segment) bool) bool {
+	top := len(idx.levels) - 1
+	for i := range idx.levels[top] {
+		if idx.visit(top, i, y, fn) {
+			return true
+		}
+	}
+	return false
+}
+
+func (idx intervalIndex) visit(
+	level, i int, y float64, fn func(segment) bool) bool {
+
+	if node := idx.levels[level][i]; y < node.min || y > node.max {
+		return false
+	}
+	if level == 0 {
+		return fn(idx.segs[i])
+	}
+	end := (i + 1) * nodeCapacity
+	if below := len(idx.levels[level-1]); end > below {
+		end = below
+	}
+	for child := i * nodeCapacity; child < end; child++ {
+		if idx.visit(level-1, child, y, fn) {
+			return true
+		}
+	}
+	return false
+}
+
+// Sorts segments and their intervals together
+type byMidpoint struct {
+	segs      []segment
+	intervals []interval
+}
+
+func (b byMidpoint) Len() int {
+	return len(b.segs)
+}
+
+func (b byMidpoint) Less(i, j int) bool {
+	return b.intervals[i].min+b.intervals[i].max <
+		b.intervals[j].min+b.intervals[j].max
+}
+
+func (b byMidpoint) Swap(i, j int) {
+	b.segs[i], b.segs[j] = b.segs[j], b.segs[i]
+	b.intervals[i], b.intervals[j] = b.intervals[j], b.intervals[i]
+}
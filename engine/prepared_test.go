@@ -0,0 +1,67 @@
+package engine
+
+import (
+	"math"
+	"testing"
+
+	"github.com/vistarmedia/geom/shape"
+)
+
+func TestPreparedPolygonLocate(t *testing.T) {
+	polys := []shape.Polygon{
+		{
+			{{X: 4, Y: 0}, {X: 8, Y: 4}, {X: 4, Y: 8}, {X: 0, Y: 4}, {X: 4, Y: 0}},
+			{{X: 3, Y: 3}, {X: 3, Y: 5}, {X: 5, Y: 5}, {X: 5, Y: 3}, {X: 3, Y: 3}},
+		},
+		{{{X: 10, Y: 0}, {X: 12, Y: 0}, {X: 12, Y: 2}, {X: 10, Y: 2},
+			{X: 10, Y: 0}}},
+	}
+	pp := NewPreparedPolygon(polys...)
+	locations := []struct {
+		c   shape.Coord
+		loc Location
+	}{
+		{shape.Coord{X: 4, Y: 0}, BOUNDARY},
+		{shape.Coord{X: 6, Y: 2}, BOUNDARY},
+		{shape.Coord{X: 3, Y: 4}, BOUNDARY},
+		{shape.Coord{X: 4, Y: 1}, INTERIOR},
+		{shape.Coord{X: 4, Y: 4}, EXTERIOR},
+		{shape.Coord{X: 2, Y: 1}, EXTERIOR},
+		// Either side of a diagonal edge
+		{shape.Coord{X: 6, Y: math.Nextafter(2, 3)}, INTERIOR},
+		{shape.Coord{X: 6, Y: math.Nextafter(2, 1)}, EXTERIOR},
+		{shape.Coord{X: 11, Y: 1}, INTERIOR},
+		{shape.Coord{X: 12, Y: 1}, BOUNDARY},
+		{shape.Coord{X: 9, Y: 1}, EXTERIOR},
+	}
+	for _, tt := range locations {
+		if loc := pp.Locate(tt.c); loc != tt.loc {
+			t.Errorf("Locate(%v): expected %d, got %d", tt.c, tt.loc, loc)
+		}
+		if loc := Locate(tt.c, polys); loc != tt.loc {
+			t.Errorf("Unprepared Locate(%v): expected %d, got %d", tt.c, tt.loc, loc)
+		}
+	}
+	edge := shape.Coord{X: 6, Y: 2}
+	if !pp.Covers(edge) || pp.Contains(edge) {
+		t.Error("Expected boundary to be covered but not contained")
+	}
+
+	if NewPreparedPolygon().Covers(shape.Coord{X: 0, Y: 0}) {
+		t.Error("Expected nothing to be covered by no polygons")
+	}
+}
+
+func BenchmarkPreparedPolygonCovers(b *testing.B) {
+	ring := make(shape.LinearRing, 50001)
+	for i := range ring {
+		angle := 2 * math.Pi * float64(i) / 50000
+		ring[i] = shape.Coord{X: math.Cos(angle), Y: math.Sin(angle)}
+	}
+	ring[50000] = ring[0]
+	pp := NewPreparedPolygon(shape.Polygon{ring})
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		pp.Covers(shape.Coord{X: 0.5, Y: 0.25})
+	}
+}
@@ -0,0 +1,91 @@
+package geom
+
+import (
+	"math"
+	"testing"
+
+	"github.com/vistarmedia/geom/engine"
+	"github.com/vistarmedia/geom/shape"
+)
+
+func TestDefaultEngine(t *testing.T) {
+	if _, ok := engine.Default().(GeosEngine); !ok {
+		t.Errorf("Expected GeosEngine, got %T", engine.Default())
+	}
+}
+
+func TestPlanarMatchesGeosEngine(t *testing.T) {
+	geos := NewGeosEngine(fact)
+	planar := engine.Planar{}
+
+	square := shape.Polygon{
+		{{0, 0}, {4, 0}, {4, 4}, {0, 4}, {0, 0}},
+		{{1, 1}, {1, 2}, {2, 2}, {2, 1}, {1, 1}},
+	}
+	shapes := []shape.Shape{
+		square,
+		shape.MultiPolygon{square, {{{5, 5}, {6, 5}, {5, 6}, {5, 5}}}},
+		shape.LineString{{0, 0}, {2, 0}, {2, 1}},
+		shape.MultiPoint{{0, 0}, {2, 4}},
+	}
+	for _, s := range shapes {
+		gArea, _ := geos.Area(s)
+		pArea, _ := planar.Area(s)
+		if math.Abs(gArea-pArea) > 1e-9 {
+			t.Errorf("Area of %v: GEOS %f, planar %f", s, gArea, pArea)
+		}
+
+		gc, err := geos.Centroid(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		pc, _ := planar.Centroid(s)
+		if math.Abs(gc.X-pc.X) > 1e-9 || math.Abs(gc.Y-pc.Y) > 1e-9 {
+			t.Errorf("Centroid of %v: GEOS %v, planar %v", s, gc, pc)
+		}
+
+		gEnv, err := geos.Envelope(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		pEnv, _ := planar.Envelope(s)
+		if eq, _ := geos.Equals(gEnv, pEnv); !eq {
+			t.Errorf("Envelope of %v: GEOS %v, planar %v", s, gEnv, pEnv)
+		}
+	}
+
+	predicates := map[string][2]func(a, b shape.Shape) (bool, error){
+		"Intersects": {geos.Intersects, planar.Intersects},
+		"Contains":   {geos.Contains, planar.Contains},
+		"Covers":     {geos.Covers, planar.Covers},
+		"Within":     {geos.Within, planar.Within},
+		"Touches":    {geos.Touches, planar.Touches},
+		"Crosses":    {geos.Crosses, planar.Crosses},
+	}
+	points := []shape.Shape{
+		shape.Point{3, 3},
+		shape.Point{4, 2},
+		shape.Point{1.5, 1.5},
+		shape.Point{1, 1.5},
+		shape.MultiPoint{{3, 3}, {4, 2}},
+		shape.MultiPoint{{3, 3}, {10, 10}},
+	}
+	for name, preds := range predicates {
+		for _, p := range points {
+			for _, args := range [][2]shape.Shape{{square, p}, {p, square}} {
+				want, err := preds[0](args[0], args[1])
+				if err != nil {
+					t.Fatal(err)
+				}
+				got, err := preds[1](args[0], args[1])
+				if err != nil {
+					t.Fatal(err)
+				}
+				if got != want {
+					t.Errorf("%s(%v, %v): GEOS %v, planar %v",
+						name, args[0], args[1], want, got)
+				}
+			}
+		}
+	}
+}
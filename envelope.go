@@ -0,0 +1,89 @@
+package geom
+
+import (
+	"errors"
+	"math"
+)
+
+var (
+	ErrEmptyGeometry = errors.New("Empty geometry")
+)
+
+// Axis aligned bounding box. Unlike Geometry.Envelope, this is a plain Go
+// value and does not hold on to any libgeos memory.
+type Envelope struct {
+	MinX, MinY, MaxX, MaxY float64
+}
+
+// Envelope of a single coordinate
+func NewEnvelope(c Coord) Envelope {
+	return Envelope{c.X, c.Y, c.X, c.Y}
+}
+
+func (e Envelope) Min() Coord {
+	return Coord{X: e.MinX, Y: e.MinY}
+}
+
+func (e Envelope) Max() Coord {
+	return Coord{X: e.MaxX, Y: e.MaxY}
+}
+
+func (e Envelope) Width() float64 {
+	return e.MaxX - e.MinX
+}
+
+func (e Envelope) Height() float64 {
+	return e.MaxY - e.MinY
+}
+
+func (e Envelope) Area() float64 {
+	return e.Width() * e.Height()
+}
+
+// True if the envelopes share any point, including their edges.
+func (e Envelope) Intersects(o Envelope) bool {
+	return e.MinX <= o.MaxX && o.MinX <= e.MaxX &&
+		e.MinY <= o.MaxY && o.MinY <= e.MaxY
+}
+
+// True if o lies entirely within e, including its edges.
+func (e Envelope) Contains(o Envelope) bool {
+	return e.MinX <= o.MinX && o.MaxX <= e.MaxX &&
+		e.MinY <= o.MinY && o.MaxY <= e.MaxY
+}
+
+// True if c lies within e, including its edges.
+func (e Envelope) ContainsCoord(c Coord) bool {
+	return e.Contains(NewEnvelope(c))
+}
+
+// Grows the envelope by d on every side. Negative values shrink it.
+func (e Envelope) Expand(d float64) Envelope {
+	return Envelope{e.MinX - d, e.MinY - d, e.MaxX + d, e.MaxY + d}
+}
+
+// Smallest envelope containing c and e
+func (e Envelope) ExpandToInclude(c Coord) Envelope {
+	return e.Union(NewEnvelope(c))
+}
+
+// Smallest envelope containing both envelopes
+func (e Envelope) Union(o Envelope) Envelope {
+	return Envelope{
+		math.Min(e.MinX, o.MinX),
+		math.Min(e.MinY, o.MinY),
+		math.Max(e.MaxX, o.MaxX),
+		math.Max(e.MaxY, o.MaxY),
+	}
+}
+
+// Minimum and maximum X and Y bounds for a geometry, read directly from
+// libgeos without building an envelope geometry. Returns ErrEmptyGeometry for
+// empty geometries. Requires libgeos 3.7.0 or greater.
+func (g *Geometry) Extent() (env Envelope, err error) {
+	err = WithHandle(g.provider(), func(ops Ops) error {
+		env, err = ops.Extent(g)
+		return err
+	})
+	return
+}
@@ -0,0 +1,85 @@
+package geom
+
+import (
+	"testing"
+)
+
+func TestEnvelopeOps(t *testing.T) {
+	e1 := Envelope{0, 0, 10, 10}
+	e2 := Envelope{5, 5, 15, 20}
+	e3 := Envelope{11, 0, 12, 1}
+
+	if !e1.Intersects(e2) || e1.Intersects(e3) {
+		t.Error("Unexpected intersection")
+	}
+	if !e1.Intersects(Envelope{10, 10, 11, 11}) {
+		t.Error("Envelopes sharing a corner should intersect")
+	}
+	if !e1.Contains(Envelope{1, 1, 10, 10}) || e1.Contains(e2) {
+		t.Error("Unexpected containment")
+	}
+	if !e1.ContainsCoord(Coord{0, 5}) || e1.ContainsCoord(Coord{-1, 5}) {
+		t.Error("Unexpected coord containment")
+	}
+	if u := e1.Union(e3); u != (Envelope{0, 0, 12, 10}) {
+		t.Errorf("Unexpected union: %v", u)
+	}
+	if x := e1.Expand(1); x != (Envelope{-1, -1, 11, 11}) {
+		t.Errorf("Unexpected expansion: %v", x)
+	}
+	if x := e1.ExpandToInclude(Coord{-2, 3}); x != (Envelope{-2, 0, 10, 10}) {
+		t.Errorf("Unexpected expansion: %v", x)
+	}
+	if e2.Area() != 150 {
+		t.Errorf("Expected area of 150, got %f", e2.Area())
+	}
+}
+
+func TestExtent(t *testing.T) {
+	poly, err := fact.NewPolygon([]Coord{
+		{30, 10},
+		{40, 40},
+		{20, 40},
+		{10, 20},
+		{30, 10},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	env, err := poly.Extent()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if env != (Envelope{10, 10, 40, 40}) {
+		t.Errorf("Unexpected envelope: %v", env)
+	}
+}
+
+func TestExtentPoint(t *testing.T) {
+	p, err := fact.NewPoint(Coord{3, 4})
+	if err != nil {
+		t.Fatal(err)
+	}
+	env, err := p.Extent()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if env != NewEnvelope(Coord{3, 4}) {
+		t.Errorf("Unexpected envelope: %v", env)
+	}
+
+	// Previously panicked, as a point's envelope is not a polygon
+	c0, c1, err := p.Bounds()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c0 != c1 || c0 != (Coord{3, 4}) {
+		t.Errorf("Unexpected bounds: %v, %v", c0, c1)
+	}
+}
+
+func TestExtentEmpty(t *testing.T) {
+	if _, err := fact.NewEmptyPolygon().Extent(); err != ErrEmptyGeometry {
+		t.Errorf("Expected %v, got %v", ErrEmptyGeometry, err)
+	}
+}
@@ -16,56 +16,155 @@ type Coord struct {
 	X, Y float64
 }
 
-func newGeosLinearRing(h *geos.Handle, coords []Coord) (*geos.Geometry, error) {
-	coordsLen := len(coords)
-	if coordsLen == 0 {
+func newGeosCoordSeq(h *geos.Handle, coords []Coord) (*geos.CoordSeq, error) {
+	if len(coords) == 0 {
 		return nil, ErrEmptyCoords
 	}
-	cs := geos.NewCoordSeq(h, uint(coordsLen), 2)
+	xy := make([]float64, 2*len(coords))
 	for i, c := range coords {
-		if err := cs.SetX(h, uint(i), c.X); err != nil {
-			cs.Destroy(h)
-			return nil, err
-		}
-		if err := cs.SetY(h, uint(i), c.Y); err != nil {
-			cs.Destroy(h)
-			return nil, err
-		}
+		xy[2*i] = c.X
+		xy[2*i+1] = c.Y
+	}
+	return geos.NewCoordSeqFromXY(h, xy)
+}
+
+func newGeosLinearRing(h *geos.Handle, coords []Coord) (*geos.Geometry, error) {
+	cs, err := newGeosCoordSeq(h, coords)
+	if err != nil {
+		return nil, err
 	}
 	// LinearRing destructor will destroy the coord seq even on error
 	return cs.LinearRing(h)
 }
 
+func newGeosLineString(h *geos.Handle, coords []Coord) (*geos.Geometry, error) {
+	cs, err := newGeosCoordSeq(h, coords)
+	if err != nil {
+		return nil, err
+	}
+	return cs.LineString(h)
+}
+
+// Clones the passed geometries in to a new collection of the given type. The
+// collection owns the clones.
+func newGeosCollection(h *geos.Handle, typeId geos.GeometryTypeId,
+	gs []*Geometry) (*geos.Geometry, error) {
+
+	if len(gs) == 0 {
+		return geos.NewEmptyGeometryCollection(h, typeId), nil
+	}
+	geoms := make([]*geos.Geometry, len(gs))
+	for i, g := range gs {
+		geoms[i] = g.g.Clone(h)
+		runtime.KeepAlive(g)
+	}
+	// The collection takes ownership of the clones, even on error
+	return geos.NewGeometryCollection(h, typeId, geoms)
+}
+
 // For creating geometries
 type Factory struct {
-	hp handle.GeosHandleProvider
+	hp       handle.GeosHandleProvider
+	gridSize float64
+	srid     int
 }
 
-func NewFactory(hp handle.GeosHandleProvider) Factory {
-	return Factory{hp}
+// Configures a Factory
+type FactoryOption func(*Factory)
+
+// Snaps the coordinates of every geometry built or decoded by the factory to a
+// grid of the given size. Results of operations on those geometries are not
+// snapped. Requires libgeos 3.6.0 or greater.
+func WithPrecision(gridSize float64) FactoryOption {
+	return func(f *Factory) {
+		f.gridSize = gridSize
+	}
 }
 
+// Sets the SRID of every geometry built or decoded by the factory.
+func WithSRID(srid int) FactoryOption {
+	return func(f *Factory) {
+		f.srid = srid
+	}
+}
+
+func NewFactory(hp handle.GeosHandleProvider, opts ...FactoryOption) Factory {
+	return Factory{hp: hp}.With(opts...)
+}
+
+// Copy of this factory with additional options
+func (f Factory) With(opts ...FactoryOption) Factory {
+	for _, opt := range opts {
+		opt(&f)
+	}
+	return f
+}
+
+// Takes ownership of a geos geometry, applying the factory's SRID. Precision is
+// not applied, as snapping can fail; see FromGeosWithOptions.
 func (f Factory) FromGeos(g *geos.Geometry) *Geometry {
-	return newGeometry(f.hp, g)
+	h := f.hp.Get()
+	defer f.hp.Put(h)
+	return f.newEmpty(h, g)
+}
+
+// Takes ownership of a geos geometry, applying the factory's precision and
+// SRID. g is destroyed on error.
+func (f Factory) FromGeosWithOptions(g *geos.Geometry) (*Geometry, error) {
+	h := f.hp.Get()
+	defer f.hp.Put(h)
+	return f.newGeometry(h, g)
+}
+
+// Wraps g in a GC-managed Geometry after applying the factory's precision and
+// SRID. Takes ownership of g, destroying it on error.
+func (f Factory) newGeometry(h *geos.Handle, g *geos.Geometry) (*Geometry, error) {
+	if f.gridSize > 0 {
+		snapped, err := g.SetPrecision(h, f.gridSize)
+		g.Destroy(h)
+		if err != nil {
+			return nil, err
+		}
+		g = snapped
+	}
+	return f.newEmpty(h, g), nil
+}
+
+func (f Factory) newGeometryOrError(
+	h *geos.Handle, g *geos.Geometry, err error) (*Geometry, error) {
+
+	if err != nil {
+		return nil, err
+	}
+	return f.newGeometry(h, g)
+}
+
+// Wraps g applying only the factory's SRID, for geometries with no coordinates
+// to snap or callers which can not handle a failed snap
+func (f Factory) newEmpty(h *geos.Handle, g *geos.Geometry) *Geometry {
+	if f.srid != 0 {
+		g.SetSRID(h, f.srid)
+	}
+	return newGeometry(f.hp, h, g)
 }
 
 func (f Factory) NewEmptyPoint() Point {
 	h := f.hp.Get()
 	defer f.hp.Put(h)
-	return newPoint(newGeometry(f.hp, geos.NewEmptyPoint(h)))
+	return newPoint(f.newEmpty(h, geos.NewEmptyPoint(h)))
 }
 
 func (f Factory) NewEmptyPolygon() Polygon {
 	h := f.hp.Get()
 	defer f.hp.Put(h)
-	return newPolygon(newGeometry(f.hp, geos.NewEmptyPolygon(h)))
+	return newPolygon(f.newEmpty(h, geos.NewEmptyPolygon(h)))
 }
 
 func (f Factory) NewEmptyMultipolygon() Multipolygon {
 	h := f.hp.Get()
 	defer f.hp.Put(h)
 	mp := geos.NewEmptyGeometryCollection(h, geos.MULTIPOLYGON)
-	return newMultiPolygon(newGeometry(f.hp, mp))
+	return newMultiPolygon(f.newEmpty(h, mp))
 }
 
 func (f Factory) NewPoint(c Coord) (p Point, err error) {
@@ -80,20 +179,45 @@ func (f Factory) NewPoint(c Coord) (p Point, err error) {
 		cs.Destroy(h)
 		return
 	}
-	if point, err := cs.Point(h); err == nil {
-		p = newPoint(newGeometry(f.hp, point))
+	point, err := cs.Point(h)
+	if err != nil {
+		return
 	}
+	g, err := f.newGeometry(h, point)
+	if err != nil {
+		return
+	}
+	p = newPoint(g)
+	return
+}
+
+func (f Factory) NewLineString(coords []Coord) (ls LineString, err error) {
+	h := f.hp.Get()
+	defer f.hp.Put(h)
+	g, err := newGeosLineString(h, coords)
+	if err != nil {
+		return
+	}
+	geom, err := f.newGeometry(h, g)
+	if err != nil {
+		return
+	}
+	ls = newLineString(geom)
 	return
 }
 
 func (f Factory) NewLinearRing(coords []Coord) (lr LinearRing, err error) {
 	h := f.hp.Get()
+	defer f.hp.Put(h)
 	g, err := newGeosLinearRing(h, coords)
-	f.hp.Put(h)
 	if err != nil {
 		return
 	}
-	lr = newLinearRing(newGeometry(f.hp, g))
+	geom, err := f.newGeometry(h, g)
+	if err != nil {
+		return
+	}
+	lr = newLinearRing(geom)
 	return
 }
 
@@ -119,7 +243,11 @@ func (f Factory) NewPolygon(
 	if err != nil {
 		return
 	}
-	p = newPolygon(newGeometry(f.hp, g))
+	geom, err := f.newGeometry(h, g)
+	if err != nil {
+		return
+	}
+	p = newPolygon(geom)
 	return
 }
 
@@ -140,6 +268,87 @@ func (f Factory) NewMultipolygon(ps ...Polygon) (mp Multipolygon, err error) {
 	if err != nil {
 		return
 	}
-	mp = newMultiPolygon(newGeometry(f.hp, g))
+	geom, err := f.newGeometry(h, g)
+	if err != nil {
+		return
+	}
+	mp = newMultiPolygon(geom)
 	return
 }
+
+// Create a MULTIPOINT from some POINTs. Like NewMultipolygon, the passed
+// POINTs are cloned.
+func (f Factory) NewMultiPoint(ps ...Point) (*Geometry, error) {
+	gs := make([]*Geometry, len(ps))
+	for i, p := range ps {
+		gs[i] = p.Geometry
+	}
+	return f.newCollection(geos.MULTIPOINT, gs)
+}
+
+// Create a MULTILINESTRING from some LINESTRINGs. Like NewMultipolygon, the
+// passed LINESTRINGs are cloned.
+func (f Factory) NewMultiLineString(ls ...LineString) (*Geometry, error) {
+	gs := make([]*Geometry, len(ls))
+	for i, l := range ls {
+		gs[i] = l.Geometry
+	}
+	return f.newCollection(geos.MULTILINESTRING, gs)
+}
+
+// Create a Scope tracking geometries created through it. See Scope.
+func (f Factory) NewScope() *Scope {
+	s := NewScope(f.hp)
+	s.factory = f
+	return s
+}
+
+// Create an empty STRtree spatial index. nodeCapacity is the maximum number of
+// children per node; 10 is a reasonable default.
+func (f Factory) NewSTRtree(nodeCapacity uint) *STRtree {
+	return newSTRtree(f.hp, nodeCapacity)
+}
+
+// Create a GEOMETRYCOLLECTION from any geometries. Like NewMultipolygon, the
+// passed geometries are cloned.
+func (f Factory) NewGeometryCollection(gs ...*Geometry) (*Geometry, error) {
+	return f.newCollection(geos.GEOMETRYCOLLECTION, gs)
+}
+
+func (f Factory) newCollection(
+	typeId geos.GeometryTypeId, gs []*Geometry) (*Geometry, error) {
+
+	h := f.hp.Get()
+	defer f.hp.Put(h)
+	g, err := newGeosCollection(h, typeId, gs)
+	return f.newGeometryOrError(h, g, err)
+}
+
+// Dissolves all passed geometries in to one using a cascaded union. This is
+// far faster than folding the slice with Geometry.Union.
+func (f Factory) UnionAll(gs []*Geometry) (*Geometry, error) {
+	return f.unionAll(gs, (*geos.Geometry).UnaryUnion)
+}
+
+// Dissolves polygons whose interiors do not overlap, such as a set of ZIP
+// code or county boundaries. See Geometry.CoverageUnion.
+func (f Factory) CoverageUnionAll(gs []*Geometry) (*Geometry, error) {
+	return f.unionAll(gs, (*geos.Geometry).CoverageUnion)
+}
+
+func (f Factory) unionAll(gs []*Geometry,
+	union func(*geos.Geometry, *geos.Handle) (*geos.Geometry, error)) (
+	*Geometry, error) {
+
+	h := f.hp.Get()
+	defer f.hp.Put(h)
+
+	coll, err := newGeosCollection(h, geos.GEOMETRYCOLLECTION, gs)
+	if err != nil {
+		return nil, err
+	}
+	defer coll.Destroy(h)
+
+	g, err := union(coll, h)
+	return f.newGeometryOrError(h, g, err)
+}
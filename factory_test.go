@@ -1,6 +1,7 @@
 package geom
 
 import (
+	"math"
 	"testing"
 
 	"github.com/vistarmedia/geom/geos-go"
@@ -43,6 +44,24 @@ func TestNewPoint(t *testing.T) {
 	}
 }
 
+func TestNewLineString(t *testing.T) {
+	coords := []Coord{{0, 0}, {3, 4}}
+	line, err := fact.NewLineString(coords)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if line.Type() != LINESTRING {
+		t.Errorf("Unexpected geom type: %d", line.Type())
+	}
+	outCoords, err := line.Coords()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !compareCoordSlice(coords, outCoords) {
+		t.Errorf("Coordinates dont match, in: %v, out %v", coords, outCoords)
+	}
+}
+
 func TestNewLinearRing(t *testing.T) {
 	coords := []Coord{
 		{2, 2},
@@ -144,3 +163,222 @@ func TestNewPolygonInvalid(t *testing.T) {
 		t.Errorf("Expected GEOS error, got %v", err)
 	}
 }
+
+// n x n grid of unit squares, each shifted by overlap so neighbors intersect
+func squareGrid(tb testing.TB, n int, overlap float64) []*Geometry {
+	var squares []*Geometry
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			x, y := float64(i), float64(j)
+			sq, err := fact.NewPolygon([]Coord{
+				{x, y},
+				{x + 1 + overlap, y},
+				{x + 1 + overlap, y + 1 + overlap},
+				{x, y + 1 + overlap},
+				{x, y},
+			})
+			if err != nil {
+				tb.Fatal(err)
+			}
+			squares = append(squares, sq.Geometry)
+		}
+	}
+	return squares
+}
+
+func TestNewGeometryCollection(t *testing.T) {
+	squares := squareGrid(t, 2, 0)
+	coll, err := fact.NewGeometryCollection(squares...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if coll.Type() != GEOMETRYCOLLECTION {
+		t.Errorf("Unexpected geom type: %d", coll.Type())
+	}
+	if n, _ := coll.NumGeometries(); n != 4 {
+		t.Errorf("Expected 4 geometries, got %d", n)
+	}
+
+	empty, err := fact.NewGeometryCollection()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if isEmpty, _ := empty.IsEmpty(); !isEmpty {
+		t.Error("Expected empty collection")
+	}
+}
+
+func TestNewMultiPointAndLineString(t *testing.T) {
+	p1, _ := fact.NewPoint(Coord{0, 0})
+	p2, _ := fact.NewPoint(Coord{1, 1})
+	mp, err := fact.NewMultiPoint(p1, p2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mp.Type() != MULTIPOINT {
+		t.Errorf("Unexpected geom type: %d", mp.Type())
+	}
+	if n, _ := mp.NumGeometries(); n != 2 {
+		t.Errorf("Expected 2 geometries, got %d", n)
+	}
+
+	ls, _ := fact.NewLineString([]Coord{{0, 0}, {1, 1}})
+	mls, err := fact.NewMultiLineString(ls)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mls.Type() != MULTILINESTRING {
+		t.Errorf("Unexpected geom type: %d", mls.Type())
+	}
+}
+
+func TestUnionAll(t *testing.T) {
+	union, err := fact.UnionAll(squareGrid(t, 3, 0.5))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if union.Type() != POLYGON {
+		t.Errorf("Unexpected geom type: %d", union.Type())
+	}
+	// 3x3 grid extended by the overlap of the last row and column
+	if union.Area() != 3.5*3.5 {
+		t.Errorf("Expected area of %f, got %f", 3.5*3.5, union.Area())
+	}
+}
+
+func TestCoverageUnionAll(t *testing.T) {
+	union, err := fact.CoverageUnionAll(squareGrid(t, 3, 0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if union.Area() != 9 {
+		t.Errorf("Expected area of 9, got %f", union.Area())
+	}
+	if union.Type() != POLYGON {
+		t.Errorf("Unexpected geom type: %d", union.Type())
+	}
+}
+
+func TestUnaryUnion(t *testing.T) {
+	coll, err := fact.NewGeometryCollection(squareGrid(t, 2, 0.5)...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	union, err := coll.UnaryUnion()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if union.Area() != 2.5*2.5 {
+		t.Errorf("Expected area of %f, got %f", 2.5*2.5, union.Area())
+	}
+}
+
+func BenchmarkUnionPairwise(b *testing.B) {
+	squares := squareGrid(b, 10, 0.5)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		union := squares[0]
+		for _, sq := range squares[1:] {
+			var err error
+			if union, err = union.Union(sq); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
+
+func BenchmarkUnionAll(b *testing.B) {
+	squares := squareGrid(b, 10, 0.5)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := fact.UnionAll(squares); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkCoverageUnionAll(b *testing.B) {
+	squares := squareGrid(b, 10, 0)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := fact.CoverageUnionAll(squares); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestFactoryOptions(t *testing.T) {
+	f := NewFactory(handle.NewPooledHandleProvider(),
+		WithPrecision(0.5), WithSRID(3857))
+
+	p, err := f.NewPoint(Coord{1.2, 3.9})
+	if err != nil {
+		t.Fatal(err)
+	}
+	c, err := p.Coord()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c != (Coord{1, 4}) {
+		t.Errorf("Expected point snapped to (1, 4), got %v", c)
+	}
+	if p.SRID() != 3857 {
+		t.Errorf("Expected SRID 3857, got %d", p.SRID())
+	}
+	if empty := f.NewEmptyPolygon(); empty.SRID() != 3857 {
+		t.Errorf("Expected empty polygon SRID 3857, got %d", empty.SRID())
+	}
+
+	scope := f.NewScope()
+	defer scope.Close()
+	scoped, err := scope.Factory().NewPoint(Coord{0, 0})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if scoped.SRID() != 3857 {
+		t.Errorf("Expected scope factory to keep options, got SRID %d",
+			scoped.SRID())
+	}
+
+	// FromGeos applies the SRID but not precision
+	g := NewFactory(f.hp, WithPrecision(10), WithSRID(4326))
+	h := f.hp.Get()
+	clone := p.UnsafeToGeos().Clone(h)
+	f.hp.Put(h)
+	raw := g.FromGeos(clone)
+	if c, _ := raw.Point().Coord(); c != (Coord{1, 4}) || raw.SRID() != 4326 {
+		t.Errorf("Expected unsnapped point with SRID 4326, got %v %d",
+			c, raw.SRID())
+	}
+	h = f.hp.Get()
+	clone = p.UnsafeToGeos().Clone(h)
+	f.hp.Put(h)
+	snapped, err := g.FromGeosWithOptions(clone)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c, _ := snapped.Point().Coord(); c != (Coord{0, 0}) {
+		t.Errorf("Expected snapped point, got %v", c)
+	}
+}
+
+// Vertices of a regular polygon, as in a large administrative boundary
+func circleCoords(n int) []Coord {
+	coords := make([]Coord, n+1)
+	for i := 0; i < n; i++ {
+		angle := 2 * math.Pi * float64(i) / float64(n)
+		coords[i] = Coord{math.Cos(angle), math.Sin(angle)}
+	}
+	coords[n] = coords[0]
+	return coords
+}
+
+func BenchmarkNewPolygon50k(b *testing.B) {
+	shell := circleCoords(50000)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := fact.NewPolygon(shell); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package geodesy
+
+import (
+	"math"
+
+	"github.com/vistarmedia/geom"
+	"github.com/vistarmedia/geom/proj"
+)
+
+// Polygon approximating the set of points within radius meters of center,
+// with the given number of vertices. Each vertex lies exactly radius meters
+// from center along the ellipsoid.
+func Circle(f geom.Factory, center geom.Coord, radius float64, segments int) (
+	geom.Polygon, error) {
+
+	shell := make([]geom.Coord, segments+1)
+	for i := 0; i < segments; i++ {
+		// Counter-clockwise from north
+		bearing := -360 * float64(i) / float64(segments)
+		c, err := Destination(center, bearing, radius)
+		if err != nil {
+			return geom.Polygon{}, err
+		}
+		shell[i] = c
+	}
+	shell[segments] = shell[0]
+	return f.NewPolygon(shell)
+}
+
+// Buffers g by width meters. g is projected to an azimuthal equidistant frame
+// centered on its bounding box, buffered in the plane with Geometry.Buffer, and
+// projected back. Distances from the center are exact, and the result is
+// accurate for geometries spanning up to a few hundred kilometers.
+func Buffer(g *geom.Geometry, width float64, quadsegs int) (
+	*geom.Geometry, error) {
+
+	env, err := g.Extent()
+	if err != nil {
+		return nil, err
+	}
+	aeqd := AzimuthalEquidistant{geom.Coord{
+		X: env.MinX + env.Width()/2,
+		Y: env.MinY + env.Height()/2,
+	}}
+	planar, err := proj.Transform(g, proj.WGS84, aeqd)
+	if err != nil {
+		return nil, err
+	}
+	buffered, err := planar.Buffer(width, quadsegs)
+	if err != nil {
+		return nil, err
+	}
+	return proj.Transform(buffered, aeqd, proj.WGS84)
+}
+
+// Projection in meters preserving distance and bearing from Center, computed
+// exactly on the ellipsoid.
+type AzimuthalEquidistant struct {
+	Center geom.Coord
+}
+
+func (AzimuthalEquidistant) SRID() int {
+	return 0
+}
+
+func (a AzimuthalEquidistant) FromLonLat(c geom.Coord) (geom.Coord, error) {
+	g, err := inverse(a.Center, c)
+	if err != nil {
+		return geom.Coord{}, err
+	}
+	sinAlpha, cosAlpha := math.Sincos(g.alpha1)
+	return geom.Coord{X: g.s * sinAlpha, Y: g.s * cosAlpha}, nil
+}
+
+func (a AzimuthalEquidistant) ToLonLat(c geom.Coord) (geom.Coord, error) {
+	bearing := degrees(math.Atan2(c.X, c.Y))
+	return Destination(a.Center, bearing, math.Hypot(c.X, c.Y))
+}
@@ -0,0 +1,130 @@
+package geodesy
+
+import (
+	"math"
+	"testing"
+
+	"github.com/vistarmedia/geom"
+)
+
+const twoMiles = 3218.688
+
+func TestDestination(t *testing.T) {
+	// Inverse of TestDistance
+	flinders := geom.Coord{dms(144, 25, 29.52440), -dms(37, 57, 3.72030)}
+	c, err := Destination(flinders, dms(306, 52, 5.37), 54972.271)
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp := geom.Coord{dms(143, 55, 35.38390), -dms(37, 39, 10.15610)}
+	if math.Abs(c.X-exp.X) > 1e-6 || math.Abs(c.Y-exp.Y) > 1e-6 {
+		t.Errorf("Expected %v, got %v", exp, c)
+	}
+}
+
+func TestDestinationAcrossAntimeridian(t *testing.T) {
+	c, err := Destination(geom.Coord{179.9, 0}, 90, 100000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.X > -179 || c.X < -180 {
+		t.Errorf("Expected longitude to wrap, got %v", c)
+	}
+}
+
+func assertWithin(t *testing.T, g *geom.Geometry, center geom.Coord,
+	min, max float64) {
+
+	t.Helper()
+	shell, err := g.Polygon().Shell()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, c := range shell {
+		d, err := Distance(center, c)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if d < min || d > max {
+			t.Errorf("Expected %v to be %f-%fm from center, was %f", c, min, max, d)
+		}
+	}
+}
+
+func TestCircle(t *testing.T) {
+	store := geom.Coord{-73.98, 40.75}
+	circle, err := Circle(fact, store, twoMiles, 64)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertWithin(t, circle.Geometry, store, twoMiles-0.001, twoMiles+0.001)
+
+	area, err := Area(circle.Geometry)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Area of a regular 64-gon inscribed in the circle
+	exp := 32 * twoMiles * twoMiles * math.Sin(2*math.Pi/64)
+	assertClose(t, "area", exp, area, 1e-3)
+}
+
+func TestBufferPoint(t *testing.T) {
+	store := geom.Coord{-73.98, 40.75}
+	p, err := fact.NewPoint(store)
+	if err != nil {
+		t.Fatal(err)
+	}
+	buffered, err := Buffer(p.Geometry, twoMiles, 8)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if buffered.Type() != geom.POLYGON {
+		t.Fatalf("Expected a polygon, got %d", buffered.Type())
+	}
+	assertWithin(t, buffered, store, twoMiles-0.01, twoMiles+0.01)
+}
+
+func TestBufferLine(t *testing.T) {
+	line, err := fact.NewLineString([]geom.Coord{{-74, 40.7}, {-73.9, 40.8}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	buffered, err := Buffer(line.Geometry, 1000, 8)
+	if err != nil {
+		t.Fatal(err)
+	}
+	length, err := Length(line.Geometry)
+	if err != nil {
+		t.Fatal(err)
+	}
+	area, err := Area(buffered)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// A 2km wide strip plus round caps
+	exp := 2*1000*length + math.Pi*1000*1000
+	assertClose(t, "area", exp, area, 0.01)
+}
+
+func TestAzimuthalEquidistant(t *testing.T) {
+	aeqd := AzimuthalEquidistant{geom.Coord{-73.98, 40.75}}
+	c := geom.Coord{-73.5, 41.2}
+	xy, err := aeqd.FromLonLat(c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	d, err := Distance(aeqd.Center, c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r := math.Hypot(xy.X, xy.Y); math.Abs(r-d) > 1e-6 {
+		t.Errorf("Expected distance from center of %f, got %f", d, r)
+	}
+	back, err := aeqd.ToLonLat(xy)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if math.Abs(back.X-c.X) > 1e-9 || math.Abs(back.Y-c.Y) > 1e-9 {
+		t.Errorf("Expected %v, got %v", c, back)
+	}
+}
@@ -0,0 +1,364 @@
+// Package geodesy measures geometries in WGS84 longitude/latitude (EPSG:4326)
+// on the ellipsoid, rather than in the plane. Coordinates are X=longitude and
+// Y=latitude in degrees. Results are in meters and square meters.
+package geodesy
+
+import (
+	"errors"
+	"math"
+
+	"github.com/vistarmedia/geom"
+)
+
+// WGS84 ellipsoid
+const (
+	SemiMajorAxis = 6378137.0
+	Flattening    = 1 / 298.257223563
+	SemiMinorAxis = SemiMajorAxis * (1 - Flattening)
+)
+
+var (
+	// Vincenty's formula does not converge for nearly antipodal points
+	ErrNoConvergence = errors.New("geodesy: Distance failed to converge")
+)
+
+const (
+	maxIterations = 200
+	convergence   = 1e-12
+)
+
+var (
+	// Squared eccentricity and second eccentricity
+	e2  = Flattening * (2 - Flattening)
+	e   = math.Sqrt(e2)
+	ep2 = e2 / (1 - e2)
+	// Radius of the sphere with the same surface area as the ellipsoid
+	authalicRadius = math.Sqrt(
+		(SemiMajorAxis * SemiMajorAxis / 2) * (1 + (1-e2)/e*math.Atanh(e)))
+)
+
+// Positive nodes and weights of 8 point Gauss-Legendre quadrature
+var gaussLegendre = [...]struct{ x, w float64 }{
+	{0.18343464249564978, 0.362683783378362},
+	{0.525532409916329, 0.3137066458778874},
+	{0.7966664774136268, 0.22238103445337445},
+	{0.9602898564975363, 0.10122853629037679},
+}
+
+// Solution of the inverse problem between two points
+type geodesic struct {
+	s float64 // length in meters
+	// Azimuths at each end and at the equator crossing, in radians
+	alpha1, alpha2       float64
+	sinAlpha0, cosAlpha0 float64
+	// Arc lengths on the auxiliary sphere from the equator crossing
+	sigma1, sigma2 float64
+}
+
+// Length in meters of the geodesic between a and b, using Vincenty's inverse
+// formula. Accurate to within a millimeter.
+func Distance(a, b geom.Coord) (float64, error) {
+	g, err := inverse(a, b)
+	return g.s, err
+}
+
+// Point reached by travelling distance meters from c along the geodesic with
+// the initial bearing, in degrees clockwise from north. Uses Vincenty's direct
+// formula.
+func Destination(c geom.Coord, bearing, distance float64) (geom.Coord, error) {
+	alpha1 := radians(bearing)
+	sinAlpha1, cosAlpha1 := math.Sincos(alpha1)
+	tanU1 := (1 - Flattening) * math.Tan(radians(c.Y))
+	cosU1 := 1 / math.Sqrt(1+tanU1*tanU1)
+	sinU1 := tanU1 * cosU1
+	sigma1 := math.Atan2(tanU1, cosAlpha1)
+	sinAlpha := cosU1 * sinAlpha1
+	cos2Alpha := 1 - sinAlpha*sinAlpha
+	A, B := coefficients(cos2Alpha)
+
+	sigma := distance / (SemiMinorAxis * A)
+	var sinSigma, cosSigma, cos2SigmaM float64
+	for i := 0; ; i++ {
+		if i == maxIterations {
+			return geom.Coord{}, ErrNoConvergence
+		}
+		cos2SigmaM = math.Cos(2*sigma1 + sigma)
+		sinSigma, cosSigma = math.Sincos(sigma)
+		prev := sigma
+		sigma = distance/(SemiMinorAxis*A) +
+			deltaSigma(B, sinSigma, cosSigma, cos2SigmaM)
+		if math.Abs(sigma-prev) < convergence {
+			break
+		}
+	}
+	sinSigma, cosSigma = math.Sincos(sigma)
+	cos2SigmaM = math.Cos(2*sigma1 + sigma)
+
+	x := sinU1*sinSigma - cosU1*cosSigma*cosAlpha1
+	phi2 := math.Atan2(sinU1*cosSigma+cosU1*sinSigma*cosAlpha1,
+		(1-Flattening)*math.Hypot(sinAlpha, x))
+	lambda := math.Atan2(sinSigma*sinAlpha1,
+		cosU1*cosSigma-sinU1*sinSigma*cosAlpha1)
+	C := Flattening / 16 * cos2Alpha * (4 + Flattening*(4-3*cos2Alpha))
+	L := lambda - (1-C)*Flattening*sinAlpha*(sigma+C*sinSigma*
+		(cos2SigmaM+C*cosSigma*(-1+2*cos2SigmaM*cos2SigmaM)))
+
+	lon := math.Remainder(radians(c.X)+L, 2*math.Pi)
+	return geom.Coord{X: degrees(lon), Y: degrees(phi2)}, nil
+}
+
+// Geodesic from a to b
+func inverse(a, b geom.Coord) (geodesic, error) {
+	L := radians(b.X - a.X)
+	U1 := math.Atan((1 - Flattening) * math.Tan(radians(a.Y)))
+	U2 := math.Atan((1 - Flattening) * math.Tan(radians(b.Y)))
+	sinU1, cosU1 := math.Sincos(U1)
+	sinU2, cosU2 := math.Sincos(U2)
+
+	lambda := L
+	for i := 0; i < maxIterations; i++ {
+		sinLambda, cosLambda := math.Sincos(lambda)
+		sinSigma := math.Hypot(cosU2*sinLambda, cosU1*sinU2-sinU1*cosU2*cosLambda)
+		if sinSigma == 0 {
+			// Coincident points
+			return geodesic{}, nil
+		}
+		cosSigma := sinU1*sinU2 + cosU1*cosU2*cosLambda
+		sigma := math.Atan2(sinSigma, cosSigma)
+		sinAlpha := cosU1 * cosU2 * sinLambda / sinSigma
+		cos2Alpha := 1 - sinAlpha*sinAlpha
+		cos2SigmaM := 0.0
+		if cos2Alpha != 0 {
+			// Zero along the equator
+			cos2SigmaM = cosSigma - 2*sinU1*sinU2/cos2Alpha
+		}
+		C := Flattening / 16 * cos2Alpha * (4 + Flattening*(4-3*cos2Alpha))
+		prev := lambda
+		lambda = L + (1-C)*Flattening*sinAlpha*(sigma+C*sinSigma*
+			(cos2SigmaM+C*cosSigma*(-1+2*cos2SigmaM*cos2SigmaM)))
+
+		if math.Abs(lambda-prev) < convergence {
+			sinLambda, cosLambda = math.Sincos(lambda)
+			alpha1 := math.Atan2(cosU2*sinLambda, cosU1*sinU2-sinU1*cosU2*cosLambda)
+			alpha2 := math.Atan2(cosU1*sinLambda, cosU1*sinU2*cosLambda-sinU1*cosU2)
+			sigma1 := math.Atan2(sinU1, cosU1*math.Cos(alpha1))
+			A, B := coefficients(cos2Alpha)
+			return geodesic{
+				s: SemiMinorAxis * A *
+					(sigma - deltaSigma(B, sinSigma, cosSigma, cos2SigmaM)),
+				alpha1:    alpha1,
+				alpha2:    alpha2,
+				sinAlpha0: sinAlpha,
+				cosAlpha0: math.Sqrt(cos2Alpha),
+				sigma1:    sigma1,
+				sigma2:    sigma1 + sigma,
+			}, nil
+		}
+	}
+	return geodesic{}, ErrNoConvergence
+}
+
+// Vincenty's A and B series for a geodesic with the given cos^2 of its
+// equatorial azimuth
+func coefficients(cos2Alpha float64) (float64, float64) {
+	a2, b2 := SemiMajorAxis*SemiMajorAxis, SemiMinorAxis*SemiMinorAxis
+	u2 := cos2Alpha * (a2 - b2) / b2
+	A := 1 + u2/16384*(4096+u2*(-768+u2*(320-175*u2)))
+	B := u2 / 1024 * (256 + u2*(-128+u2*(74-47*u2)))
+	return A, B
+}
+
+func deltaSigma(B, sinSigma, cosSigma, cos2SigmaM float64) float64 {
+	return B * sinSigma * (cos2SigmaM + B/4*
+		(cosSigma*(-1+2*cos2SigmaM*cos2SigmaM)-
+			B/6*cos2SigmaM*(-3+4*sinSigma*sinSigma)*
+				(-3+4*cos2SigmaM*cos2SigmaM)))
+}
+
+// Total geodesic length of the lines in g. Points and polygons have no
+// length; see Perimeter.
+func Length(g *geom.Geometry) (float64, error) {
+	switch g.Type() {
+	case geom.LINESTRING:
+		coords, err := g.LineString().Coords()
+		if err != nil {
+			return 0, err
+		}
+		return lineLength(coords)
+	case geom.LINEARRING:
+		coords, err := g.LinearRing().Coords()
+		if err != nil {
+			return 0, err
+		}
+		return lineLength(coords)
+	case geom.MULTILINESTRING, geom.GEOMETRYCOLLECTION:
+		return sum(g, Length)
+	default:
+		return 0, nil
+	}
+}
+
+// Total geodesic length of the shells and holes of the polygons in g
+func Perimeter(g *geom.Geometry) (float64, error) {
+	switch g.Type() {
+	case geom.POLYGON:
+		rings, err := polygonRings(g.Polygon())
+		if err != nil {
+			return 0, err
+		}
+		total := 0.0
+		for _, ring := range rings {
+			length, err := lineLength(ring)
+			if err != nil {
+				return 0, err
+			}
+			total += length
+		}
+		return total, nil
+	case geom.MULTIPOLYGON, geom.GEOMETRYCOLLECTION:
+		return sum(g, Perimeter)
+	default:
+		return 0, nil
+	}
+}
+
+// Area of the polygons in g on the ellipsoid, with edges following geodesics.
+// Uses Karney's method, summing the area between each edge and the equator.
+// Polygons containing a pole are not supported.
+func Area(g *geom.Geometry) (float64, error) {
+	switch g.Type() {
+	case geom.POLYGON:
+		rings, err := polygonRings(g.Polygon())
+		if err != nil || len(rings) == 0 {
+			return 0, err
+		}
+		area := 0.0
+		for i, ring := range rings {
+			ra, err := ringArea(ring)
+			if err != nil {
+				return 0, err
+			}
+			if i == 0 {
+				area += math.Abs(ra)
+			} else {
+				area -= math.Abs(ra)
+			}
+		}
+		return area, nil
+	case geom.MULTIPOLYGON, geom.GEOMETRYCOLLECTION:
+		return sum(g, Area)
+	default:
+		return 0, nil
+	}
+}
+
+func sum(g *geom.Geometry, measure func(*geom.Geometry) (float64, error)) (
+	float64, error) {
+
+	gs, err := g.Geometries()
+	if err != nil {
+		return 0, err
+	}
+	total := 0.0
+	for _, g := range gs {
+		m, err := measure(g)
+		if err != nil {
+			return 0, err
+		}
+		total += m
+	}
+	return total, nil
+}
+
+// Shell followed by holes. Empty for an empty polygon.
+func polygonRings(p geom.Polygon) ([][]geom.Coord, error) {
+	if isEmpty, err := p.IsEmpty(); err != nil || isEmpty {
+		return nil, err
+	}
+	shell, err := p.Shell()
+	if err != nil {
+		return nil, err
+	}
+	holes, err := p.Holes()
+	if err != nil {
+		return nil, err
+	}
+	return append([][]geom.Coord{shell}, holes...), nil
+}
+
+func lineLength(coords []geom.Coord) (float64, error) {
+	total := 0.0
+	for i := 1; i < len(coords); i++ {
+		d, err := Distance(coords[i-1], coords[i])
+		if err != nil {
+			return 0, err
+		}
+		total += d
+	}
+	return total, nil
+}
+
+// Signed area of a closed ring, positive when counter-clockwise
+func ringArea(coords []geom.Coord) (float64, error) {
+	area := 0.0
+	for i := 1; i < len(coords); i++ {
+		g, err := inverse(coords[i-1], coords[i])
+		if err != nil {
+			return 0, err
+		}
+		area -= g.equatorArea()
+	}
+	return area, nil
+}
+
+// Area between the geodesic and the equator, from Karney's "Algorithms for
+// geodesics" (2013), equations 58 and 59. The integral I4 is evaluated by
+// quadrature rather than with its series expansion.
+func (g geodesic) equatorArea() float64 {
+	area := authalicRadius * authalicRadius * (g.alpha2 - g.alpha1)
+	if g.sinAlpha0 == 0 || g.cosAlpha0 == 0 {
+		// Meridians and the equator
+		return area
+	}
+	k2 := ep2 * g.cosAlpha0 * g.cosAlpha0
+	mid, half := (g.sigma1+g.sigma2)/2, (g.sigma2-g.sigma1)/2
+	integral := 0.0
+	for _, gl := range gaussLegendre {
+		for _, sigma := range [2]float64{mid - half*gl.x, mid + half*gl.x} {
+			sinSigma := math.Sin(sigma)
+			integral += gl.w * dividedT(ep2, k2*sinSigma*sinSigma) * sinSigma / 2
+		}
+	}
+	// I4(sigma2) - I4(sigma1)
+	dI4 := -integral * half
+	return area + e2*SemiMajorAxis*SemiMajorAxis*g.cosAlpha0*g.sinAlpha0*dI4
+}
+
+// (t(x) - t(y)) / (x - y), where t(x) = x + sqrt(1/x + 1) asinh(sqrt(x)),
+// summed as a power series to avoid cancellation when x and y are close.
+// Converges for x, y < 1.
+func dividedT(x, y float64) float64 {
+	// Coefficients of asinh(sqrt(x)) / sqrt(x (1 + x)) and the sum of
+	// x^j y^(n-1-j) over j < n
+	d, p, yn := 1.0, 1.0, 1.0
+	total := 1.0
+	for n := 1; n < 64; n++ {
+		term := d / float64(2*n+1) * p
+		total += term
+		if math.Abs(term) < 1e-17*total {
+			break
+		}
+		d *= -float64(2*n) / float64(2*n+1)
+		yn *= y
+		p = x*p + yn
+	}
+	return total
+}
+
+func radians(deg float64) float64 {
+	return deg * math.Pi / 180
+}
+
+func degrees(rad float64) float64 {
+	return rad * 180 / math.Pi
+}
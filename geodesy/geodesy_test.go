@@ -0,0 +1,145 @@
+package geodesy
+
+import (
+	"math"
+	"testing"
+
+	"github.com/vistarmedia/geom"
+	"github.com/vistarmedia/geom/geos-go/handle"
+)
+
+var fact = geom.NewFactory(handle.NewPooledHandleProvider())
+
+func assertClose(t *testing.T, name string, exp, act, relTol float64) {
+	t.Helper()
+	if math.Abs(act-exp) > math.Abs(exp)*relTol {
+		t.Errorf("Expected %s of %f, got %f", name, exp, act)
+	}
+}
+
+func dms(d, m, s float64) float64 {
+	return d + m/60 + s/3600
+}
+
+func TestDistance(t *testing.T) {
+	// Flinders Peak to Buninyong, from Vincenty's 1975 paper
+	flinders := geom.Coord{dms(144, 25, 29.52440), -dms(37, 57, 3.72030)}
+	buninyong := geom.Coord{dms(143, 55, 35.38390), -dms(37, 39, 10.15610)}
+	d, err := Distance(flinders, buninyong)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if math.Abs(d-54972.271) > 0.001 {
+		t.Errorf("Expected 54972.271m, got %f", d)
+	}
+
+	if d, err := Distance(flinders, flinders); err != nil || d != 0 {
+		t.Errorf("Expected 0 between coincident points, got %f, %v", d, err)
+	}
+}
+
+func TestDistanceAntipodal(t *testing.T) {
+	_, err := Distance(geom.Coord{0, 0}, geom.Coord{179.7, 0.5})
+	if err != ErrNoConvergence {
+		t.Errorf("Expected ErrNoConvergence, got %v", err)
+	}
+}
+
+func TestArea(t *testing.T) {
+	square, err := fact.NewPolygon(
+		[]geom.Coord{{0, 0}, {1, 0}, {1, 1}, {0, 1}, {0, 0}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	area, err := Area(square.Geometry)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// From GeographicLib's Planimeter
+	assertClose(t, "area", 12308778361.469, area, 1e-9)
+
+	// Winding order doesn't matter
+	reversed, err := fact.NewPolygon(
+		[]geom.Coord{{0, 0}, {0, 1}, {1, 1}, {1, 0}, {0, 0}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reversedArea, _ := Area(reversed.Geometry); reversedArea != area {
+		t.Errorf("Expected area of %f, got %f", area, reversedArea)
+	}
+}
+
+func TestAreaAcrossAntimeridian(t *testing.T) {
+	square, err := fact.NewPolygon([]geom.Coord{
+		{179.5, 0}, {-179.5, 0}, {-179.5, 1}, {179.5, 1}, {179.5, 0}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	area, err := Area(square.Geometry)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertClose(t, "area", 12308778361.469, area, 1e-9)
+}
+
+func TestAreaWithHoles(t *testing.T) {
+	outer, err := fact.NewPolygon(
+		[]geom.Coord{{0, 0}, {2, 0}, {2, 2}, {0, 2}, {0, 0}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	holed, err := fact.NewPolygon(
+		[]geom.Coord{{0, 0}, {2, 0}, {2, 2}, {0, 2}, {0, 0}},
+		[]geom.Coord{{0, 0}, {1, 0}, {1, 1}, {0, 1}, {0, 0}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	outerArea, _ := Area(outer.Geometry)
+	holedArea, err := Area(holed.Geometry)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertClose(t, "area", outerArea-12308778361.469, holedArea, 1e-9)
+
+	mp, err := fact.NewMultipolygon(outer, holed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	mpArea, err := Area(mp.Geometry)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertClose(t, "area", outerArea+holedArea, mpArea, 1e-12)
+}
+
+func TestLengthAndPerimeter(t *testing.T) {
+	line, err := fact.NewLineString([]geom.Coord{{0, 0}, {1, 0}, {1, 1}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	length, err := Length(line.Geometry)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// One degree of the equator plus one degree of the meridian
+	assertClose(t, "length", 111319.491+110574.389, length, 1e-6)
+
+	if p, _ := Perimeter(line.Geometry); p != 0 {
+		t.Errorf("Expected lines to have no perimeter, got %f", p)
+	}
+
+	square, err := fact.NewPolygon(
+		[]geom.Coord{{0, 0}, {1, 0}, {1, 1}, {0, 1}, {0, 0}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	perimeter, err := Perimeter(square.Geometry)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertClose(t, "perimeter", 443770.918, perimeter, 1e-5)
+
+	if l, _ := Length(square.Geometry); l != 0 {
+		t.Errorf("Expected polygons to have no length, got %f", l)
+	}
+}
@@ -4,9 +4,12 @@ import (
 	"fmt"
 	"runtime"
 	"sync"
+	"sync/atomic"
 
+	"github.com/vistarmedia/geom/engine"
 	"github.com/vistarmedia/geom/geos-go"
 	"github.com/vistarmedia/geom/geos-go/handle"
+	"github.com/vistarmedia/geom/shape"
 )
 
 type GeometryType int
@@ -25,8 +28,43 @@ const (
 // See OGC Simple Feature Specification for geometry operation details:
 // http://portal.opengeospatial.org/files/?artifact_id=25355
 type Geometry struct {
-	hp handle.GeosHandleProvider
+	// Holds a providerRef. Replaced by Scope.Keep, so always read with provider.
+	hp atomic.Value
 	g  *geos.Geometry
+	// Approximate native memory, for Stats
+	size      int64
+	closeOnce sync.Once
+}
+
+type providerRef struct {
+	handle.GeosHandleProvider
+}
+
+func (g *Geometry) provider() handle.GeosHandleProvider {
+	return g.hp.Load().(providerRef).GeosHandleProvider
+}
+
+// Handle providers which track the geometries created through them, like Scope
+type tracker interface {
+	track(*Geometry)
+	trackPrepared(*PreparedGeometry)
+}
+
+// Tracker behind hp, looking through wrappers such as
+// handle.InstrumentedHandleProvider which have an Unwrap method
+func trackerOf(hp handle.GeosHandleProvider) tracker {
+	for {
+		if t, ok := hp.(tracker); ok {
+			return t
+		}
+		wrapper, ok := hp.(interface {
+			Unwrap() handle.GeosHandleProvider
+		})
+		if !ok {
+			return nil
+		}
+		hp = wrapper.Unwrap()
+	}
 }
 
 type toGeos interface {
@@ -38,79 +76,125 @@ type unaryPredicate func(*geos.Handle) (bool, error)
 type binaryOp func(*geos.Handle, *geos.Geometry) (*geos.Geometry, error)
 type binaryPredicate func(*geos.Handle, *geos.Geometry) (bool, error)
 
+// Takes ownership of g. h must be a handle currently leased from hp, so that
+// no more than one handle is leased at a time.
 func newGeometry(
-	hp handle.GeosHandleProvider, g *geos.Geometry) *Geometry {
+	hp handle.GeosHandleProvider, h *geos.Handle, g *geos.Geometry) *Geometry {
 
 	geom := &Geometry{
-		hp: hp,
-		g:  g,
+		g:    g,
+		size: nativeSize(h, g),
+	}
+	geom.hp.Store(providerRef{hp})
+	stats.allocGeometry(geom.size)
+	runtime.SetFinalizer(geom, (*Geometry).finalize)
+	if t := trackerOf(hp); t != nil {
+		t.track(geom)
 	}
-	runtime.SetFinalizer(geom, func(geom1 *Geometry) {
-		h := geom1.hp.Get()
-		geom1.g.Destroy(h)
-		geom1.hp.Put(h)
-	})
 	return geom
 }
 
+func (g *Geometry) destroy(h *geos.Handle) {
+	g.g.Destroy(h)
+	stats.freeGeometry(g.size)
+}
+
+func (g *Geometry) finalize() {
+	handle.Finalize(g.destroy)
+}
+
+// Frees the underlying libgeos geometry immediately rather than waiting for
+// the GC. The geometry, and any PreparedGeometry created from it, must not be
+// used afterwards. Closing more than once, or from several goroutines, has no
+// effect.
+func (g *Geometry) Close() {
+	g.closeOnce.Do(func() {
+		runtime.SetFinalizer(g, nil)
+		hp := g.provider()
+		h := hp.Get()
+		g.destroy(h)
+		hp.Put(h)
+		g.g = nil
+	})
+}
+
 func newGeometryOrError(
 	hp handle.GeosHandleProvider,
+	h *geos.Handle,
 	g *geos.Geometry,
 	err error) (*Geometry, error) {
 
 	if err != nil {
 		return nil, err
 	} else {
-		return newGeometry(hp, g), nil
+		return newGeometry(hp, h, g), nil
 	}
 }
 
 func (g *Geometry) unaryOperation(op unaryOp) (*Geometry, error) {
-	h := g.hp.Get()
+	hp := g.provider()
+	h := hp.Get()
+	defer hp.Put(h)
+	defer runtime.KeepAlive(g)
 	geom, err := op(h)
-	g.hp.Put(h)
-	return newGeometryOrError(g.hp, geom, err)
+	return newGeometryOrError(hp, h, geom, err)
 }
 
 func (g *Geometry) unaryPredicate(op unaryPredicate) (bool, error) {
-	h := g.hp.Get()
-	defer g.hp.Put(h)
+	hp := g.provider()
+	h := hp.Get()
+	defer hp.Put(h)
+	defer runtime.KeepAlive(g)
 	return op(h)
 }
 
 func (g *Geometry) binaryOperation(op binaryOp, o toGeos) (*Geometry, error) {
-	h := g.hp.Get()
+	hp := g.provider()
+	h := hp.Get()
+	defer hp.Put(h)
+	defer runtime.KeepAlive(g)
 	geom, err := op(h, o.UnsafeToGeos())
 	runtime.KeepAlive(o)
-	g.hp.Put(h)
-	return newGeometryOrError(g.hp, geom, err)
+	return newGeometryOrError(hp, h, geom, err)
 }
 
 func (g *Geometry) binaryPredicate(op binaryPredicate, o toGeos) (bool, error) {
-	h := g.hp.Get()
+	hp := g.provider()
+	h := hp.Get()
 	val, err := op(h, o.UnsafeToGeos())
+	runtime.KeepAlive(g)
 	runtime.KeepAlive(o)
-	g.hp.Put(h)
+	hp.Put(h)
 	return val, err
 }
 
 func (g *Geometry) Prepared() *PreparedGeometry {
-	h := g.hp.Get()
+	hp := g.provider()
+	h := hp.Get()
 	p := g.g.Prepared(h)
-	g.hp.Put(h)
+	runtime.KeepAlive(g)
+	hp.Put(h)
 	prep := &PreparedGeometry{
-		hp:     g.hp,
+		hp:     hp,
 		p:      p,
 		parent: g,
+		size:   g.size,
+	}
+	stats.allocPrepared(prep.size)
+	runtime.SetFinalizer(prep, (*PreparedGeometry).finalize)
+	if t := trackerOf(hp); t != nil {
+		t.trackPrepared(prep)
 	}
-	runtime.SetFinalizer(prep, func(prep1 *PreparedGeometry) {
-		h := prep1.hp.Get()
-		prep1.p.Destroy(h)
-		prep1.hp.Put(h)
-	})
 	return prep
 }
 
+// Factory sharing this geometry's handle provider, for building related
+// geometries. Options of the factory which built this geometry are not
+// inherited.
+func (g *Geometry) Factory(opts ...FactoryOption) Factory {
+	return NewFactory(g.provider(), opts...)
+}
+
 // Unsafe access to the geos geometry. This geometry is still subject to GC.
 // For internal use only.
 func (g *Geometry) UnsafeToGeos() *geos.Geometry {
@@ -118,10 +202,24 @@ func (g *Geometry) UnsafeToGeos() *geos.Geometry {
 }
 
 func (g *Geometry) Type() GeometryType {
-	h := g.hp.Get()
+	hp := g.provider()
+	h := hp.Get()
 	id := g.g.TypeId(h)
-	g.hp.Put(h)
+	runtime.KeepAlive(g)
+	hp.Put(h)
+	return geometryType(id)
+}
+
+// Spatial reference system identifier. 0 if unset. See WithSRID.
+func (g *Geometry) SRID() int {
+	hp := g.provider()
+	h := hp.Get()
+	defer hp.Put(h)
+	defer runtime.KeepAlive(g)
+	return g.g.SRID(h)
+}
 
+func geometryType(id geos.GeometryTypeId) GeometryType {
 	switch id {
 	case geos.POINT:
 		return POINT
@@ -145,26 +243,66 @@ func (g *Geometry) Type() GeometryType {
 }
 
 func (g *Geometry) Area() float64 {
-	h := g.hp.Get()
-	defer g.hp.Put(h)
+	hp := g.provider()
+	h := hp.Get()
+	defer hp.Put(h)
+	defer runtime.KeepAlive(g)
 
 	return g.g.Area(h)
 }
 
+// Minimum cartesian distance between this geometry and o.
+func (g *Geometry) Distance(o toGeos) (float64, error) {
+	hp := g.provider()
+	h := hp.Get()
+	dist, err := g.g.Distance(h, o.UnsafeToGeos())
+	runtime.KeepAlive(g)
+	runtime.KeepAlive(o)
+	hp.Put(h)
+	return dist, err
+}
+
 func (g *Geometry) ClipByRect(
 	xmin, ymin, xmax, ymax float64) (*Geometry, error) {
 
-	h := g.hp.Get()
+	hp := g.provider()
+	h := hp.Get()
+	defer hp.Put(h)
+	defer runtime.KeepAlive(g)
 	geom, err := g.g.ClipByRect(h, xmin, ymin, xmax, ymax)
-	g.hp.Put(h)
-	return newGeometryOrError(g.hp, geom, err)
+	return newGeometryOrError(hp, h, geom, err)
 }
 
 func (g *Geometry) Buffer(width float64, quadsegs int) (*Geometry, error) {
-	h := g.hp.Get()
+	hp := g.provider()
+	h := hp.Get()
+	defer hp.Put(h)
+	defer runtime.KeepAlive(g)
 	geom, err := g.g.Buffer(h, width, quadsegs)
-	g.hp.Put(h)
-	return newGeometryOrError(g.hp, geom, err)
+	return newGeometryOrError(hp, h, geom, err)
+}
+
+// Simplifies the geometry with the Douglas-Peucker algorithm. Vertices closer
+// than tolerance to the simplified line are dropped. The result may not be
+// valid; polygons can self intersect or collapse entirely.
+func (g *Geometry) Simplify(tolerance float64) (*Geometry, error) {
+	hp := g.provider()
+	h := hp.Get()
+	defer hp.Put(h)
+	defer runtime.KeepAlive(g)
+	geom, err := g.g.Simplify(h, tolerance)
+	return newGeometryOrError(hp, h, geom, err)
+}
+
+// Like Simplify, but will not change the topology of the geometry. Rings will
+// not collapse or cross each other. Slower than Simplify.
+func (g *Geometry) TopologyPreserveSimplify(tolerance float64) (*Geometry, error) {
+	hp := g.provider()
+	h := hp.Get()
+	defer hp.Put(h)
+	defer runtime.KeepAlive(g)
+	geom, err := g.g.TopologyPreserveSimplify(h, tolerance)
+	return newGeometryOrError(hp, h, geom, err)
 }
 
 func (g *Geometry) Intersection(o toGeos) (*Geometry, error) {
@@ -175,6 +313,25 @@ func (g *Geometry) Union(o toGeos) (*Geometry, error) {
 	return g.binaryOperation(g.g.Union, o)
 }
 
+// Unions all the components of this geometry together. For dissolving many
+// geometries at once, see Factory.UnionAll.
+func (g *Geometry) UnaryUnion() (*Geometry, error) {
+	return g.unaryOperation(g.g.UnaryUnion)
+}
+
+// Like UnaryUnion, but much faster for collections of polygons whose
+// interiors do not overlap, such as adjacent administrative boundaries. The
+// result is undefined if the polygons do overlap.
+func (g *Geometry) CoverageUnion() (*Geometry, error) {
+	return g.unaryOperation(g.g.CoverageUnion)
+}
+
+// Center of mass as a Point. Only the highest dimension parts of a collection
+// contribute, so a polygon's centroid ignores any points alongside it.
+func (g *Geometry) Centroid() (*Geometry, error) {
+	return g.unaryOperation(g.g.Centroid)
+}
+
 func (g *Geometry) Envelope() (*Geometry, error) {
 	return g.unaryOperation(g.g.Envelope)
 }
@@ -203,32 +360,61 @@ func (g *Geometry) Within(o toGeos) (bool, error) {
 	return g.binaryPredicate(g.g.Within, o)
 }
 
+func (g *Geometry) Crosses(o toGeos) (bool, error) {
+	return g.binaryPredicate(g.g.Crosses, o)
+}
+
+// Like Contains, but true for geometries lying on the boundary. A polygon
+// covers a point on its shell, but does not contain it.
+func (g *Geometry) Covers(o toGeos) (bool, error) {
+	return g.binaryPredicate(g.g.Covers, o)
+}
+
+// Like Within, but true for geometries lying on the boundary of o.
+func (g *Geometry) CoveredBy(o toGeos) (bool, error) {
+	return g.binaryPredicate(g.g.CoveredBy, o)
+}
+
+// Topological equality. True if both geometries cover the same points,
+// regardless of vertex order or redundant vertices.
+func (g *Geometry) Equals(o toGeos) (bool, error) {
+	return g.binaryPredicate(g.g.Equals, o)
+}
+
+// Structural equality. True if both geometries have the same type and the same
+// vertices in the same order, with each vertex within tolerance of its
+// counterpart. Normalize both sides first to ignore vertex ordering.
+func (g *Geometry) EqualsExact(o toGeos, tolerance float64) (bool, error) {
+	return g.binaryPredicate(func(h *geos.Handle, o *geos.Geometry) (bool, error) {
+		return g.g.EqualsExact(h, o, tolerance)
+	}, o)
+}
+
+// Returns a copy of this geometry in normal form. Rings are started at their
+// lowest vertex with a consistent orientation and components are sorted, so
+// two normalized geometries can be compared with EqualsExact.
+func (g *Geometry) Normalize() (*Geometry, error) {
+	return g.unaryOperation(func(h *geos.Handle) (*geos.Geometry, error) {
+		clone := g.g.Clone(h)
+		if err := clone.Normalize(h); err != nil {
+			clone.Destroy(h)
+			return nil, err
+		}
+		return clone, nil
+	})
+}
+
 func (g *Geometry) IsEmpty() (bool, error) {
 	return g.unaryPredicate(g.g.IsEmpty)
 }
 
-// Minimum and maximum X and Y bounds for a geometry. This will cast the
-// envelope to a Polygon, get the envelope, then inspect the shell directly.
-// We can inspect exact array positions because, according to the geos docs,
-// "When Envelope objects are created or initialized, the supplies extent values
-// are automatically sorted into the correct order."
+// Minimum and maximum X and Y bounds for a geometry. See Extent.
 func (g *Geometry) Bounds() (c0 Coord, c1 Coord, err error) {
-	var (
-		env   *Geometry
-		shell []Coord
-	)
-
-	// TODO: envelope, polygon, and shell are all going to get and return a handle
-	// to the pool. We should just re-use the same handle.
-	if env, err = g.Envelope(); err != nil {
-		return
-	}
-
-	if shell, err = env.Polygon().Shell(); err != nil {
-		return
-	}
-
-	return shell[0], shell[2], nil
+	err = WithHandle(g.provider(), func(ops Ops) error {
+		c0, c1, err = ops.Bounds(g)
+		return err
+	})
+	return
 }
 
 // Coerces to Point. Panics if the underlying type doesnt match.
@@ -239,6 +425,15 @@ func (g *Geometry) Point() Point {
 	return newPoint(g)
 }
 
+// Coerces to LineString. Panics if the underlying type doesnt match.
+func (g *Geometry) LineString() LineString {
+	if id := g.Type(); id != LINESTRING {
+		panic(fmt.Sprintf(
+			"Cannot cast geom with type %d to LINESTRING (%d)", id, LINESTRING))
+	}
+	return newLineString(g)
+}
+
 // Coerces to LinearRing. Panics if the underlying type doesnt match.
 func (g *Geometry) LinearRing() LinearRing {
 	if id := g.Type(); id != LINEARRING {
@@ -260,8 +455,10 @@ func (g *Geometry) Polygon() Polygon {
 // Number of geometries in this geometry. Non-collection types will always
 // return 1.
 func (g *Geometry) NumGeometries() (int, error) {
-	h := g.hp.Get()
-	defer g.hp.Put(h)
+	hp := g.provider()
+	h := hp.Get()
+	defer hp.Put(h)
+	defer runtime.KeepAlive(g)
 
 	n, err := g.g.NumGeometries(h)
 	if err != nil {
@@ -274,15 +471,17 @@ func (g *Geometry) NumGeometries() (int, error) {
 // will only accept 0. Collection retains ownership of the underlying geometry.
 // A GC-managed clone is returned.
 func (g *Geometry) GeometryN(n int) (*Geometry, error) {
-	h := g.hp.Get()
-	defer g.hp.Put(h)
+	hp := g.provider()
+	h := hp.Get()
+	defer hp.Put(h)
+	defer runtime.KeepAlive(g)
 
 	owned, err := g.g.GeometryN(h, n)
 	if err != nil {
 		return nil, err
 	}
 	cloned := owned.Clone(h)
-	return newGeometry(g.hp, cloned), nil
+	return newGeometry(hp, h, cloned), nil
 }
 
 // Slice of all geometries of this geometry. If this is not a geometry
@@ -313,20 +512,66 @@ type PreparedGeometry struct {
 	parent *Geometry
 	// PreparedGeoms are not thread safe. Lock operations.
 	sync.Mutex
+	// Approximate native memory, for Stats
+	size int64
 }
 
-func (pg *PreparedGeometry) Covers(o toGeos) (bool, error) {
+func (pg *PreparedGeometry) destroy(h *geos.Handle) {
+	pg.p.Destroy(h)
+	stats.freePrepared(pg.size)
+}
+
+func (pg *PreparedGeometry) finalize() {
+	handle.Finalize(pg.destroy)
+}
+
+// Frees the underlying libgeos prepared geometry immediately rather than
+// waiting for the GC. The parent geometry is unaffected. Closing more than once
+// has no effect.
+func (pg *PreparedGeometry) Close() {
+	pg.Lock()
+	defer pg.Unlock()
+	if pg.p == nil {
+		return
+	}
+	runtime.SetFinalizer(pg, nil)
+	h := pg.hp.Get()
+	pg.destroy(h)
+	pg.hp.Put(h)
+	pg.p = nil
+	pg.parent = nil
+}
+
+func (pg *PreparedGeometry) predicate(
+	op binaryPredicate, o toGeos) (bool, error) {
+
 	h := pg.hp.Get()
 	defer pg.hp.Put(h)
 	pg.Lock()
 	defer pg.Unlock()
 
-	val, err := pg.p.Covers(h, o.UnsafeToGeos())
+	val, err := op(h, o.UnsafeToGeos())
 	runtime.KeepAlive(o)
 	runtime.KeepAlive(pg.parent)
 	return val, err
 }
 
+func (pg *PreparedGeometry) Covers(o toGeos) (bool, error) {
+	return pg.predicate(pg.p.Covers, o)
+}
+
+func (pg *PreparedGeometry) Contains(o toGeos) (bool, error) {
+	return pg.predicate(pg.p.Contains, o)
+}
+
+func (pg *PreparedGeometry) Intersects(o toGeos) (bool, error) {
+	return pg.predicate(pg.p.Intersects, o)
+}
+
+func (pg *PreparedGeometry) Overlaps(o toGeos) (bool, error) {
+	return pg.predicate(pg.p.Overlaps, o)
+}
+
 // Point
 type Point struct {
 	*Geometry
@@ -337,13 +582,39 @@ func newPoint(g *Geometry) Point {
 }
 
 func (p Point) Coord() (Coord, error) {
-	h := p.hp.Get()
-	defer p.hp.Put(h)
+	hp := p.provider()
+	h := hp.Get()
+	defer hp.Put(h)
+	defer runtime.KeepAlive(p.Geometry)
 	cs, err := p.g.CoordSeq(h)
 	if err != nil {
 		return Coord{}, err
 	}
-	return Coord{cs.X(h, 0), cs.Y(h, 0)}, nil
+	if cs.Size(h) == 0 {
+		return Coord{}, nil
+	}
+	var xy [2]float64
+	if err := cs.CopyXY(h, xy[:]); err != nil {
+		return Coord{}, err
+	}
+	return Coord{X: xy[0], Y: xy[1]}, nil
+}
+
+// LineString
+type LineString struct {
+	*Geometry
+}
+
+func newLineString(g *Geometry) LineString {
+	return LineString{g}
+}
+
+func (ls LineString) Coords() (coords []Coord, err error) {
+	err = WithHandle(ls.provider(), func(ops Ops) error {
+		coords, err = ops.Coords(ls.Geometry)
+		return err
+	})
+	return
 }
 
 // LinearRing
@@ -356,15 +627,10 @@ func newLinearRing(g *Geometry) LinearRing {
 }
 
 func (lr LinearRing) Coords() (coords []Coord, err error) {
-	h := lr.hp.Get()
-	defer lr.hp.Put(h)
-	cs, err := lr.g.CoordSeq(h)
-	if err != nil {
-		return
-	}
-	for i := uint(0); i < cs.Size(h); i++ {
-		coords = append(coords, Coord{cs.X(h, i), cs.Y(h, i)})
-	}
+	err = WithHandle(lr.provider(), func(ops Ops) error {
+		coords, err = ops.Coords(lr.Geometry)
+		return err
+	})
 	return
 }
 
@@ -378,51 +644,51 @@ func newPolygon(g *Geometry) Polygon {
 }
 
 func (p Polygon) Shell() (coords []Coord, err error) {
-	h := p.hp.Get()
-	defer p.hp.Put(h)
-	geosShell, err := p.g.ExteriorRing(h)
-	if err != nil {
-		return
-	}
-	cs, err := geosShell.CoordSeq(h)
-	if err != nil {
-		return
-	}
-	// It would be nice if this could just return a LinearRing, but the polygon
-	// owns the shell so we would have to clone it to be safe.
-	for i := uint(0); i < cs.Size(h); i++ {
-		coords = append(coords, Coord{cs.X(h, i), cs.Y(h, i)})
-	}
+	err = WithHandle(p.provider(), func(ops Ops) error {
+		coords, err = ops.Shell(p)
+		return err
+	})
 	return
 }
 
 func (p Polygon) Holes() (coords [][]Coord, err error) {
-	h := p.hp.Get()
-	defer p.hp.Put(h)
-	numRings, err := p.g.NumInteriorRings(h)
-	if err != nil {
-		return
-	}
-	for ringIdx := 0; ringIdx < numRings; ringIdx++ {
-		var geosRing *geos.Geometry
-		geosRing, err = p.g.InteriorRingN(h, ringIdx)
+	err = WithHandle(p.provider(), func(ops Ops) error {
+		coords, err = ops.Holes(p)
+		return err
+	})
+	return
+}
+
+// Copies the polygon's rings out in to a pure Go prepared polygon, which
+// answers Contains and Covers for points without calling in to GEOS
+func (p Polygon) PreparePlanar() (pp *engine.PreparedPolygon, err error) {
+	err = WithHandle(p.provider(), func(ops Ops) error {
+		shell, err := ops.Shell(p)
 		if err != nil {
-			return
+			return err
 		}
-		var cs *geos.CoordSeq
-		cs, err = geosRing.CoordSeq(h)
+		holes, err := ops.Holes(p)
 		if err != nil {
-			return
+			return err
 		}
-		var ringCoords []Coord
-		for i := uint(0); i < cs.Size(h); i++ {
-			ringCoords = append(ringCoords, Coord{cs.X(h, i), cs.Y(h, i)})
+		poly := shape.Polygon{shapeRing(shell)}
+		for _, hole := range holes {
+			poly = append(poly, shapeRing(hole))
 		}
-		coords = append(coords, ringCoords)
-	}
+		pp = engine.NewPreparedPolygon(poly)
+		return nil
+	})
 	return
 }
 
+func shapeRing(coords []Coord) shape.LinearRing {
+	ring := make(shape.LinearRing, len(coords))
+	for i, c := range coords {
+		ring[i] = shape.Coord(c)
+	}
+	return ring
+}
+
 // Multipolygon
 type Multipolygon struct {
 	*Geometry
@@ -2,9 +2,12 @@ package geom
 
 import (
 	"fmt"
+	"math"
+	"math/rand"
 	"testing"
 
 	"github.com/vistarmedia/geom/geos-go/handle"
+	"github.com/vistarmedia/geom/shape"
 )
 
 func TestIntersection(t *testing.T) {
@@ -42,6 +45,118 @@ func TestIntersection(t *testing.T) {
 	if intersection.Area() != 50 {
 		t.Errorf("Expected area of 50, got %f", intersection.Area())
 	}
+	exp, err := fact.NewPolygon([]Coord{
+		{5, 0},
+		{10, 0},
+		{10, 10},
+		{5, 10},
+		{5, 0},
+	})
+	if err != nil {
+		t.Error(err)
+	}
+	if eq, err := intersection.Equals(exp); err != nil {
+		t.Error(err)
+	} else if !eq {
+		t.Errorf("Unexpected intersection")
+	}
+}
+
+func TestEquals(t *testing.T) {
+	fact := NewFactory(handle.NewPooledHandleProvider())
+	square1, err := fact.NewPolygon([]Coord{
+		{0, 0},
+		{10, 0},
+		{10, 10},
+		{0, 10},
+		{0, 0},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Same square, starting at a different vertex with the opposite winding and
+	// a redundant vertex
+	square2, err := fact.NewPolygon([]Coord{
+		{10, 10},
+		{10, 5},
+		{10, 0},
+		{0, 0},
+		{0, 10},
+		{10, 10},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if eq, err := square1.Equals(square2); err != nil {
+		t.Fatal(err)
+	} else if !eq {
+		t.Error("Expected squares to be topologically equal")
+	}
+	if eq, err := square1.EqualsExact(square2, 0); err != nil {
+		t.Fatal(err)
+	} else if eq {
+		t.Error("Expected squares to not be exactly equal")
+	}
+}
+
+func TestEqualsExactTolerance(t *testing.T) {
+	fact := NewFactory(handle.NewPooledHandleProvider())
+	p1, err := fact.NewPoint(Coord{1, 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	p2, err := fact.NewPoint(Coord{1.05, 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if eq, err := p1.EqualsExact(p2, 0.1); err != nil {
+		t.Fatal(err)
+	} else if !eq {
+		t.Error("Expected points to be equal within 0.1")
+	}
+	if eq, err := p1.EqualsExact(p2, 0.01); err != nil {
+		t.Fatal(err)
+	} else if eq {
+		t.Error("Expected points to differ at 0.01")
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	fact := NewFactory(handle.NewPooledHandleProvider())
+	shell1 := []Coord{{0, 0}, {10, 0}, {10, 10}, {0, 10}, {0, 0}}
+	shell2 := []Coord{{10, 10}, {0, 10}, {0, 0}, {10, 0}, {10, 10}}
+	square1, err := fact.NewPolygon(shell1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	square2, err := fact.NewPolygon(shell2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	norm1, err := square1.Normalize()
+	if err != nil {
+		t.Fatal(err)
+	}
+	norm2, err := square2.Normalize()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if eq, err := norm1.EqualsExact(norm2, 0); err != nil {
+		t.Fatal(err)
+	} else if !eq {
+		t.Error("Expected normalized squares to be exactly equal")
+	}
+
+	// The original is left untouched
+	outShell, err := square2.Shell()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !compareCoordSlice(shell2, outShell) {
+		t.Errorf("Shell was modified: %v", outShell)
+	}
 }
 
 func TestPreparedCovers(t *testing.T) {
@@ -78,6 +193,153 @@ func TestPreparedCovers(t *testing.T) {
 	}
 }
 
+func TestPreparePlanarMatchesPreparedCovers(t *testing.T) {
+	// Star with a hole, on a lattice so that many lattice points fall exactly
+	// on its diagonal edges
+	star, err := fact.NewPolygon(
+		[]Coord{{0, 0}, {8, 4}, {16, 0}, {12, 8}, {16, 16}, {8, 12}, {0, 16},
+			{4, 8}, {0, 0}},
+		[]Coord{{6, 6}, {6, 10}, {10, 10}, {10, 6}, {6, 6}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	pp, err := star.PreparePlanar()
+	if err != nil {
+		t.Fatal(err)
+	}
+	prep := star.Prepared()
+
+	rnd := rand.New(rand.NewSource(1))
+	for i := 0; i < 2000; i++ {
+		c := Coord{
+			math.Round(rnd.Float64()*72)/4 - 1,
+			math.Round(rnd.Float64()*72)/4 - 1,
+		}
+		if i%2 == 1 {
+			// Off the lattice, mostly near the diagonal edges
+			c.X += rnd.Float64() * 1e-9
+		}
+		point, err := fact.NewPoint(c)
+		if err != nil {
+			t.Fatal(err)
+		}
+		covers, err := prep.Covers(point)
+		if err != nil {
+			t.Fatal(err)
+		}
+		contains, err := prep.Contains(point)
+		if err != nil {
+			t.Fatal(err)
+		}
+		sc := shape.Coord(c)
+		if pp.Covers(sc) != covers || pp.Contains(sc) != contains {
+			t.Errorf("%v: GEOS covers %t contains %t, planar covers %t contains %t",
+				c, covers, contains, pp.Covers(sc), pp.Contains(sc))
+		}
+	}
+}
+
+func TestPreparedIntersects(t *testing.T) {
+	fact := NewFactory(handle.NewPooledHandleProvider())
+	square, err := fact.NewPolygon([]Coord{
+		{0, 0},
+		{10, 0},
+		{10, 10},
+		{0, 10},
+		{0, 0},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	prep := square.Prepared()
+	crossing, err := fact.NewLineString([]Coord{{-5, 5}, {5, 5}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	outside, err := fact.NewLineString([]Coord{{-5, 5}, {-1, 5}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if val, err := prep.Intersects(crossing); err != nil {
+		t.Fatal(err)
+	} else if !val {
+		t.Error("Expected crossing line to intersect")
+	}
+	if val, err := prep.Intersects(outside); err != nil {
+		t.Fatal(err)
+	} else if val {
+		t.Error("Expected outside line to not intersect")
+	}
+}
+
+func TestCovers(t *testing.T) {
+	fact := NewFactory(handle.NewPooledHandleProvider())
+	square, err := fact.NewPolygon([]Coord{
+		{0, 0},
+		{10, 0},
+		{10, 10},
+		{0, 10},
+		{0, 0},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	edgePoint, err := fact.NewPoint(Coord{10, 5})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	contains, err := square.Contains(edgePoint)
+	if err != nil {
+		t.Fatal(err)
+	}
+	covers, err := square.Covers(edgePoint)
+	if err != nil {
+		t.Fatal(err)
+	}
+	coveredBy, err := edgePoint.CoveredBy(square)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if contains || !covers || !coveredBy {
+		t.Errorf("Edge point contains: %t, covers: %t, covered by: %t",
+			contains, covers, coveredBy)
+	}
+}
+
+func TestCrosses(t *testing.T) {
+	fact := NewFactory(handle.NewPooledHandleProvider())
+	square, err := fact.NewPolygon([]Coord{
+		{0, 0},
+		{10, 0},
+		{10, 10},
+		{0, 10},
+		{0, 0},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	through, err := fact.NewLineString([]Coord{{-5, 5}, {15, 5}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	inside, err := fact.NewLineString([]Coord{{2, 5}, {8, 5}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if crosses, err := through.Crosses(square); err != nil {
+		t.Fatal(err)
+	} else if !crosses {
+		t.Error("Expected line through square to cross it")
+	}
+	if crosses, err := inside.Crosses(square); err != nil {
+		t.Fatal(err)
+	} else if crosses {
+		t.Error("Expected line inside square to not cross it")
+	}
+}
+
 func TestCoordFromPoint(t *testing.T) {
 	fact := NewFactory(handle.NewPooledHandleProvider())
 	point, err := fact.NewPoint(Coord{5, 10})
@@ -257,3 +519,86 @@ func compareCoordSlice(a, b []Coord) bool {
 	}
 	return true
 }
+
+func TestStats(t *testing.T) {
+	MeasureNativeBytes(true)
+	defer MeasureNativeBytes(false)
+	fact := NewFactory(handle.NewPooledHandleProvider())
+	// Live counts may drop at any time as the GC finalizes geometries from other
+	// tests, but totals only grow when geometries are created.
+	before := Stats()
+	poly, err := fact.NewPolygon([]Coord{{0, 0}, {1, 0}, {1, 1}, {0, 0}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	prep := poly.Prepared()
+	during := Stats()
+
+	if n := during.TotalGeometries - before.TotalGeometries; n != 1 {
+		t.Errorf("Expected 1 new geometry, got %d", n)
+	}
+	if n := during.TotalPreparedGeometries - before.TotalPreparedGeometries; n != 1 {
+		t.Errorf("Expected 1 new prepared geometry, got %d", n)
+	}
+	exp := int64(geometryOverhead + 4*coordinateSize)
+	if poly.size != exp || prep.size != exp {
+		t.Errorf("Expected size of %d, got %d and %d", exp, poly.size, prep.size)
+	}
+
+	prep.Close()
+	poly.Close()
+	after := Stats()
+
+	MeasureNativeBytes(false)
+	unmeasured, err := fact.NewPoint(Coord{0, 0})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if unmeasured.size != 0 {
+		t.Errorf("Expected no size when not measuring, got %d", unmeasured.size)
+	}
+	if after.LiveGeometries > during.LiveGeometries-1 ||
+		after.LivePreparedGeometries > during.LivePreparedGeometries-1 ||
+		after.NativeBytes > during.NativeBytes-2*exp {
+		t.Errorf("Expected closed geometries to be released, before: %v, after: %v",
+			during, after)
+	}
+}
+
+func BenchmarkShell50k(b *testing.B) {
+	poly, err := fact.NewPolygon(circleCoords(50000))
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := poly.Shell(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// The per-vertex path Shell used before bulk copies, for comparison
+func BenchmarkShell50kPerVertex(b *testing.B) {
+	poly, err := fact.NewPolygon(circleCoords(50000))
+	if err != nil {
+		b.Fatal(err)
+	}
+	h := fact.hp.Get()
+	defer fact.hp.Put(h)
+	shell, err := poly.g.ExteriorRing(h)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		cs, err := shell.CoordSeq(h)
+		if err != nil {
+			b.Fatal(err)
+		}
+		coords := make([]Coord, cs.Size(h))
+		for j := range coords {
+			coords[j] = Coord{cs.X(h, uint(j)), cs.Y(h, uint(j))}
+		}
+	}
+}
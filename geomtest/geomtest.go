@@ -0,0 +1,48 @@
+// Package geomtest provides helpers for testing code which uses geom.
+package geomtest
+
+import (
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/vistarmedia/geom"
+)
+
+// How long CheckLeaks waits for finalizers to free unreachable geometries
+var LeakTimeout = 2 * time.Second
+
+// Fails the test if it finishes with more live geometries or prepared
+// geometries than it started with. Geometries which are unreachable when the
+// test finishes are not leaks, and are given a chance to be freed by the GC.
+// Counts are global, so this can not be used with parallel tests. Call at the
+// start of a test:
+//
+//	func TestThing(t *testing.T) {
+//		geomtest.CheckLeaks(t)
+//		...
+//	}
+func CheckLeaks(t testing.TB) {
+	t.Helper()
+	before := geom.Stats()
+	t.Cleanup(func() {
+		after := geom.Stats()
+		deadline := time.Now().Add(LeakTimeout)
+		for leaked(before, after) && time.Now().Before(deadline) {
+			// Finalizers run on their own goroutine after a GC cycle
+			runtime.GC()
+			time.Sleep(10 * time.Millisecond)
+			after = geom.Stats()
+		}
+		if leaked(before, after) {
+			t.Errorf("Leaked %d geometries and %d prepared geometries",
+				after.LiveGeometries-before.LiveGeometries,
+				after.LivePreparedGeometries-before.LivePreparedGeometries)
+		}
+	})
+}
+
+func leaked(before, after geom.MemStats) bool {
+	return after.LiveGeometries > before.LiveGeometries ||
+		after.LivePreparedGeometries > before.LivePreparedGeometries
+}
@@ -0,0 +1,66 @@
+package geomtest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/vistarmedia/geom"
+	"github.com/vistarmedia/geom/geos-go/handle"
+)
+
+var (
+	fact = geom.NewFactory(handle.NewPooledHandleProvider())
+	// Deliberately leaked by TestCheckLeaksDetectsLeak
+	leak []*geom.Geometry
+)
+
+func TestCheckLeaksUnreachable(t *testing.T) {
+	CheckLeaks(t)
+	for i := 0; i < 10; i++ {
+		if _, err := fact.NewPoint(geom.Coord{X: 1, Y: 2}); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestCheckLeaksClosed(t *testing.T) {
+	CheckLeaks(t)
+	p, err := fact.NewPoint(geom.Coord{X: 1, Y: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	p.Prepared().Close()
+	p.Close()
+}
+
+// Records failures rather than failing the test
+type recorder struct {
+	testing.TB
+	cleanups []func()
+	failed   bool
+}
+
+func (r *recorder) Cleanup(fn func())                         { r.cleanups = append(r.cleanups, fn) }
+func (r *recorder) Errorf(format string, args ...interface{}) { r.failed = true }
+func (r *recorder) Helper()                                   {}
+
+func TestCheckLeaksDetectsLeak(t *testing.T) {
+	defer func(timeout time.Duration) {
+		LeakTimeout = timeout
+	}(LeakTimeout)
+	LeakTimeout = 100 * time.Millisecond
+
+	r := &recorder{TB: t}
+	CheckLeaks(r)
+	p, err := fact.NewPoint(geom.Coord{X: 1, Y: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	leak = append(leak, p.Geometry)
+	for _, fn := range r.cleanups {
+		fn()
+	}
+	if !r.failed {
+		t.Error("Expected leak to be detected")
+	}
+}
@@ -1,5 +1,5 @@
 // Package geos implements bindings to the reentrant libgeos API.
-// Requires libgeos 3.5.0 or greater to be linked. Not thread or memory safe.
+// Requires libgeos 3.12.0 or greater to be linked. Not thread or memory safe.
 package geos
 
 // #cgo LDFLAGS: -lgeos_c
@@ -27,6 +27,22 @@ const (
 	GEOMETRYCOLLECTION GeometryTypeId = C.GEOS_GEOMETRYCOLLECTION
 )
 
+type BufCapStyle int
+
+const (
+	CAP_ROUND  BufCapStyle = C.GEOSBUF_CAP_ROUND
+	CAP_FLAT   BufCapStyle = C.GEOSBUF_CAP_FLAT
+	CAP_SQUARE BufCapStyle = C.GEOSBUF_CAP_SQUARE
+)
+
+type BufJoinStyle int
+
+const (
+	JOIN_ROUND BufJoinStyle = C.GEOSBUF_JOIN_ROUND
+	JOIN_MITRE BufJoinStyle = C.GEOSBUF_JOIN_MITRE
+	JOIN_BEVEL BufJoinStyle = C.GEOSBUF_JOIN_BEVEL
+)
+
 // Errors
 var (
 	ErrIndexOutOfBounds = errors.New("Index out of bounds")
@@ -41,6 +57,14 @@ func predicate(char C.char) (bool, error) {
 	return char == 1, nil
 }
 
+// GEOS setters return 0 on exception
+func setter(ret C.int) error {
+	if ret == 0 {
+		return ErrGeos
+	}
+	return nil
+}
+
 // Wraps a GEOS handle to provide access to the reentrant API.
 // Not goroutine-safe.
 type Handle struct {
@@ -135,6 +159,40 @@ func (cs *CoordSeq) LinearRing(h *Handle) (*Geometry, error) {
 	return nil, ErrGeos
 }
 
+func (cs *CoordSeq) LineString(h *Handle) (*Geometry, error) {
+	if geom := C.GEOSGeom_createLineString_r(h.h, cs.cs); geom != nil {
+		return &Geometry{geom}, nil
+	}
+	return nil, ErrGeos
+}
+
+// Creates a 2D sequence from interleaved X and Y values in a single call.
+// Requires libgeos 3.10.0 or greater.
+func NewCoordSeqFromXY(h *Handle, xy []float64) (*CoordSeq, error) {
+	if len(xy) == 0 || len(xy)%2 != 0 {
+		return nil, ErrIndexOutOfBounds
+	}
+	buf := (*C.double)(unsafe.Pointer(&xy[0]))
+	cs := C.GEOSCoordSeq_copyFromBuffer_r(h.h, buf, C.uint(len(xy)/2), 0, 0)
+	if cs == nil {
+		return nil, ErrGeos
+	}
+	return &CoordSeq{cs}, nil
+}
+
+// Copies interleaved X and Y values in to xy, which must hold 2*Size values,
+// in a single call. Requires libgeos 3.10.0 or greater.
+func (cs *CoordSeq) CopyXY(h *Handle, xy []float64) error {
+	if uint(len(xy)) != 2*cs.size(h.h) {
+		return ErrIndexOutOfBounds
+	}
+	if len(xy) == 0 {
+		return nil
+	}
+	buf := (*C.double)(unsafe.Pointer(&xy[0]))
+	return setter(C.GEOSCoordSeq_copyToBuffer_r(h.h, cs.cs, buf, 0, 0))
+}
+
 func (cs *CoordSeq) checkIdx(handle C.GEOSContextHandle_t, idx uint) error {
 	if idx < 0 || idx >= cs.size(handle) {
 		return ErrIndexOutOfBounds
@@ -210,12 +268,29 @@ func (g *Geometry) TypeId(h *Handle) GeometryTypeId {
 	return GeometryTypeId(C.GEOSGeomTypeId_r(h.h, g.g))
 }
 
+// Total number of coordinates in the geometry and all of its components
+func (g *Geometry) NumCoordinates(h *Handle) (int, error) {
+	n := int(C.GEOSGetNumCoordinates_r(h.h, g.g))
+	if n < 0 {
+		return 0, ErrGeos
+	}
+	return n, nil
+}
+
 func (g *Geometry) Area(h *Handle) float64 {
 	var area C.double
 	C.GEOSArea_r(h.h, g.g, &area)
 	return float64(area)
 }
 
+func (g *Geometry) Distance(h *Handle, o *Geometry) (float64, error) {
+	var dist C.double
+	if C.GEOSDistance_r(h.h, g.g, o.g, &dist) == 0 {
+		return 0, ErrGeos
+	}
+	return float64(dist), nil
+}
+
 func (g *Geometry) Prepared(h *Handle) *PreparedGeometry {
 	return &PreparedGeometry{C.GEOSPrepare_r(h.h, g.g)}
 }
@@ -241,6 +316,50 @@ func (g *Geometry) Buffer(
 	return &Geometry{geom}, nil
 }
 
+func (g *Geometry) BufferWithParams(
+	h *Handle, params *BufferParams, width float64) (*Geometry, error) {
+
+	geom := C.GEOSBufferWithParams_r(h.h, g.g, params.p, C.double(width))
+	if geom == nil {
+		return nil, ErrGeos
+	}
+	return &Geometry{geom}, nil
+}
+
+// Can only be called with LineStrings. Positive widths offset to the left,
+// negative to the right.
+func (g *Geometry) OffsetCurve(h *Handle, width float64, quadsegs int,
+	joinStyle BufJoinStyle, mitreLimit float64) (*Geometry, error) {
+
+	geom := C.GEOSOffsetCurve_r(h.h, g.g, C.double(width), C.int(quadsegs),
+		C.int(joinStyle), C.double(mitreLimit))
+	if geom == nil {
+		return nil, ErrGeos
+	}
+	return &Geometry{geom}, nil
+}
+
+// Douglas-Peucker simplification. May produce invalid polygons.
+func (g *Geometry) Simplify(h *Handle, tolerance float64) (*Geometry, error) {
+	geom := C.GEOSSimplify_r(h.h, g.g, C.double(tolerance))
+	if geom == nil {
+		return nil, ErrGeos
+	}
+	return &Geometry{geom}, nil
+}
+
+// Douglas-Peucker simplification which will not introduce self intersections
+// or collapse rings.
+func (g *Geometry) TopologyPreserveSimplify(
+	h *Handle, tolerance float64) (*Geometry, error) {
+
+	geom := C.GEOSTopologyPreserveSimplify_r(h.h, g.g, C.double(tolerance))
+	if geom == nil {
+		return nil, ErrGeos
+	}
+	return &Geometry{geom}, nil
+}
+
 // Can only be called with LineString, LinearRing, and Point. Parent Geometry
 // retains ownership of the coordseq
 func (g *Geometry) CoordSeq(h *Handle) (*CoordSeq, error) {
@@ -267,6 +386,37 @@ func (g *Geometry) Union(h *Handle, o *Geometry) (*Geometry, error) {
 	return &Geometry{geom}, nil
 }
 
+// Unions all components of this geometry. Faster than repeated pairwise
+// Union calls for large collections.
+func (g *Geometry) UnaryUnion(h *Handle) (*Geometry, error) {
+	geom := C.GEOSUnaryUnion_r(h.h, g.g)
+	if geom == nil {
+		return nil, ErrGeos
+	}
+	return &Geometry{geom}, nil
+}
+
+// Unions a collection of polygons which may share edges but whose interiors
+// do not overlap. Results are undefined for overlapping inputs. Requires
+// libgeos 3.8.0 or greater.
+func (g *Geometry) CoverageUnion(h *Handle) (*Geometry, error) {
+	geom := C.GEOSCoverageUnion_r(h.h, g.g)
+	if geom == nil {
+		return nil, ErrGeos
+	}
+	return &Geometry{geom}, nil
+}
+
+// Center of mass of the geometry's highest dimension components. Empty for an
+// empty geometry.
+func (g *Geometry) Centroid(h *Handle) (*Geometry, error) {
+	geom := C.GEOSGetCentroid_r(h.h, g.g)
+	if geom == nil {
+		return nil, ErrGeos
+	}
+	return &Geometry{geom}, nil
+}
+
 func (g *Geometry) Envelope(h *Handle) (*Geometry, error) {
 	geom := C.GEOSEnvelope_r(h.h, g.g)
 	if geom == nil {
@@ -275,6 +425,21 @@ func (g *Geometry) Envelope(h *Handle) (*Geometry, error) {
 	return &Geometry{geom}, nil
 }
 
+// Minimum and maximum coordinates of the geometry. Errors for empty
+// geometries. Requires libgeos 3.7.0 or greater.
+func (g *Geometry) Extent(
+	h *Handle) (xmin, ymin, xmax, ymax float64, err error) {
+
+	var x0, y0, x1, y1 C.double
+	if C.GEOSGeom_getXMin_r(h.h, g.g, &x0) == 0 ||
+		C.GEOSGeom_getYMin_r(h.h, g.g, &y0) == 0 ||
+		C.GEOSGeom_getXMax_r(h.h, g.g, &x1) == 0 ||
+		C.GEOSGeom_getYMax_r(h.h, g.g, &y1) == 0 {
+		return 0, 0, 0, 0, ErrGeos
+	}
+	return float64(x0), float64(y0), float64(x1), float64(y1), nil
+}
+
 // Can only be called with polygons. Polygon retains ownership of ring
 func (g *Geometry) ExteriorRing(h *Handle) (*Geometry, error) {
 	geom := C.GEOSGetExteriorRing_r(h.h, g.g)
@@ -326,6 +491,55 @@ func (g *Geometry) Within(h *Handle, o *Geometry) (bool, error) {
 	return predicate(C.GEOSWithin_r(h.h, g.g, o.g))
 }
 
+func (g *Geometry) Crosses(h *Handle, o *Geometry) (bool, error) {
+	return predicate(C.GEOSCrosses_r(h.h, g.g, o.g))
+}
+
+func (g *Geometry) Covers(h *Handle, o *Geometry) (bool, error) {
+	return predicate(C.GEOSCovers_r(h.h, g.g, o.g))
+}
+
+func (g *Geometry) CoveredBy(h *Handle, o *Geometry) (bool, error) {
+	return predicate(C.GEOSCoveredBy_r(h.h, g.g, o.g))
+}
+
+func (g *Geometry) Equals(h *Handle, o *Geometry) (bool, error) {
+	return predicate(C.GEOSEquals_r(h.h, g.g, o.g))
+}
+
+func (g *Geometry) EqualsExact(
+	h *Handle, o *Geometry, tolerance float64) (bool, error) {
+
+	return predicate(C.GEOSEqualsExact_r(h.h, g.g, o.g, C.double(tolerance)))
+}
+
+// Rewrites this geometry in place to its normal form.
+func (g *Geometry) Normalize(h *Handle) error {
+	if C.GEOSNormalize_r(h.h, g.g) == -1 {
+		return ErrGeos
+	}
+	return nil
+}
+
+// Snaps this geometry's coordinates to a grid of the given size, returning a
+// new geometry. Requires libgeos 3.6.0 or greater.
+func (g *Geometry) SetPrecision(h *Handle, gridSize float64) (*Geometry, error) {
+	geom := C.GEOSGeom_setPrecision_r(h.h, g.g, C.double(gridSize), 0)
+	if geom == nil {
+		return nil, ErrGeos
+	}
+	return &Geometry{geom}, nil
+}
+
+// Spatial reference system identifier. 0 if unset.
+func (g *Geometry) SRID(h *Handle) int {
+	return int(C.GEOSGetSRID_r(h.h, g.g))
+}
+
+func (g *Geometry) SetSRID(h *Handle, srid int) {
+	C.GEOSSetSRID_r(h.h, g.g, C.int(srid))
+}
+
 func (g *Geometry) IsEmpty(h *Handle) (bool, error) {
 	return predicate(C.GEOSisEmpty_r(h.h, g.g))
 }
@@ -361,6 +575,57 @@ func (pg *PreparedGeometry) Covers(h *Handle, o *Geometry) (bool, error) {
 	return predicate(C.GEOSPreparedCovers_r(h.h, pg.pg, o.g))
 }
 
+func (pg *PreparedGeometry) Intersects(h *Handle, o *Geometry) (bool, error) {
+	return predicate(C.GEOSPreparedIntersects_r(h.h, pg.pg, o.g))
+}
+
+func (pg *PreparedGeometry) Contains(h *Handle, o *Geometry) (bool, error) {
+	return predicate(C.GEOSPreparedContains_r(h.h, pg.pg, o.g))
+}
+
+func (pg *PreparedGeometry) Overlaps(h *Handle, o *Geometry) (bool, error) {
+	return predicate(C.GEOSPreparedOverlaps_r(h.h, pg.pg, o.g))
+}
+
+// http://geos.osgeo.org/doxygen/classgeos_1_1operation_1_1buffer_1_1BufferParameters.html
+// Not thread safe.
+type BufferParams struct {
+	p *C.GEOSBufferParams
+}
+
+func NewBufferParams(h *Handle) *BufferParams {
+	return &BufferParams{C.GEOSBufferParams_create_r(h.h)}
+}
+
+func (p *BufferParams) Destroy(h *Handle) {
+	C.GEOSBufferParams_destroy_r(h.h, p.p)
+}
+
+func (p *BufferParams) SetEndCapStyle(h *Handle, style BufCapStyle) error {
+	return setter(C.GEOSBufferParams_setEndCapStyle_r(h.h, p.p, C.int(style)))
+}
+
+func (p *BufferParams) SetJoinStyle(h *Handle, style BufJoinStyle) error {
+	return setter(C.GEOSBufferParams_setJoinStyle_r(h.h, p.p, C.int(style)))
+}
+
+func (p *BufferParams) SetMitreLimit(h *Handle, limit float64) error {
+	return setter(C.GEOSBufferParams_setMitreLimit_r(h.h, p.p, C.double(limit)))
+}
+
+func (p *BufferParams) SetQuadrantSegments(h *Handle, quadsegs int) error {
+	return setter(
+		C.GEOSBufferParams_setQuadrantSegments_r(h.h, p.p, C.int(quadsegs)))
+}
+
+func (p *BufferParams) SetSingleSided(h *Handle, singleSided bool) error {
+	var val C.int
+	if singleSided {
+		val = 1
+	}
+	return setter(C.GEOSBufferParams_setSingleSided_r(h.h, p.p, val))
+}
+
 // http://geos.osgeo.org/doxygen/classgeos_1_1io_1_1WKBReader.html
 // Not thread safe.
 type WKBReader struct {
@@ -409,6 +674,28 @@ func (w *WKBWriter) Write(h *Handle, g *Geometry) []byte {
 	return C.GoBytes(wkb, C.int(size))
 }
 
+// Byte order of written WKB
+type WKBByteOrder int
+
+const (
+	BIG_ENDIAN    WKBByteOrder = C.GEOS_WKB_XDR
+	LITTLE_ENDIAN WKBByteOrder = C.GEOS_WKB_NDR
+)
+
+func (w *WKBWriter) SetByteOrder(h *Handle, order WKBByteOrder) {
+	C.GEOSWKBWriter_setByteOrder_r(h.h, w.w, C.int(order))
+}
+
+// Writes the geometry's SRID, producing EWKB
+func (w *WKBWriter) SetIncludeSRID(h *Handle, include bool) {
+	C.GEOSWKBWriter_setIncludeSRID_r(h.h, w.w, cBool(include))
+}
+
+// 2 or 3
+func (w *WKBWriter) SetOutputDimension(h *Handle, dim int) {
+	C.GEOSWKBWriter_setOutputDimension_r(h.h, w.w, C.int(dim))
+}
+
 // http://geos.osgeo.org/doxygen/classgeos_1_1io_1_1WKTReader.html
 type WKTReader struct {
 	r *C.GEOSWKTReader
@@ -450,3 +737,25 @@ func (w *WKTWriter) Write(h *Handle, g *Geometry) string {
 	defer C.free(unsafe.Pointer(str))
 	return C.GoString(str)
 }
+
+// Trims trailing zeros from written coordinates
+func (w *WKTWriter) SetTrim(h *Handle, trim bool) {
+	C.GEOSWKTWriter_setTrim_r(h.h, w.w, cBool(trim))
+}
+
+// Number of decimal places written. Negative values use full precision.
+func (w *WKTWriter) SetRoundingPrecision(h *Handle, precision int) {
+	C.GEOSWKTWriter_setRoundingPrecision_r(h.h, w.w, C.int(precision))
+}
+
+// 2 or 3
+func (w *WKTWriter) SetOutputDimension(h *Handle, dim int) {
+	C.GEOSWKTWriter_setOutputDimension_r(h.h, w.w, C.int(dim))
+}
+
+func cBool(b bool) C.char {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -1,7 +1,9 @@
 #include <stdarg.h>
+#include <stdint.h>
 #include <stdio.h>
 #include <stdlib.h>
 #include <geos_c.h>
+#include "_cgo_export.h"
 
 
 void notice(const char *fmt, ...) {
@@ -27,3 +29,77 @@ void error(const char *fmt, ...) {
 GEOSContextHandle_t createGEOSHandle() {
   return initGEOS_r(notice, error);
 }
+
+static void strtreeItemCallback(void *item, void *userdata) {
+  goSTRtreeItemCallback((size_t)item, (uintptr_t)userdata);
+}
+
+static int strtreeDistanceCallback(const void *item1, const void *item2,
+    double *distance, void *userdata) {
+  return goSTRtreeDistanceCallback(
+      (size_t)item1, (size_t)item2, distance, (uintptr_t)userdata);
+}
+
+void strtreeInsert(GEOSContextHandle_t h, GEOSSTRtree *t,
+    const GEOSGeometry *g, size_t item) {
+  GEOSSTRtree_insert_r(h, t, g, (void *)item);
+}
+
+void strtreeQuery(GEOSContextHandle_t h, GEOSSTRtree *t,
+    const GEOSGeometry *g, uintptr_t userdata) {
+  GEOSSTRtree_query_r(h, t, g, strtreeItemCallback, (void *)userdata);
+}
+
+void strtreeIterate(GEOSContextHandle_t h, GEOSSTRtree *t, uintptr_t userdata) {
+  GEOSSTRtree_iterate_r(h, t, strtreeItemCallback, (void *)userdata);
+}
+
+size_t strtreeNearest(GEOSContextHandle_t h, GEOSSTRtree *t,
+    const GEOSGeometry *g, uintptr_t userdata) {
+  return (size_t)GEOSSTRtree_nearest_generic_r(
+      h, t, NULL, g, strtreeDistanceCallback, (void *)userdata);
+}
+
+#if GEOS_VERSION_MAJOR > 3 || (GEOS_VERSION_MAJOR == 3 && GEOS_VERSION_MINOR >= 14)
+
+// Interrupts are per handle, so raising one flag only aborts the operation
+// running on its own handle.
+static int interruptCallback(void *flag) {
+  return __atomic_load_n((int *)flag, __ATOMIC_SEQ_CST);
+}
+
+void setInterruptFlag(GEOSContextHandle_t h, int *flag) {
+  GEOSContext_setInterruptCallback_r(
+      h, flag == NULL ? NULL : interruptCallback, flag);
+}
+
+#else
+
+// Before 3.14 GEOS only supports a single global interrupt callback, so the
+// flag for the operation running on this thread is thread local to avoid
+// aborting operations running on other threads.
+static __thread int *interruptFlag = NULL;
+static GEOSInterruptCallback *prevInterruptCallback = NULL;
+static int interruptInstalled = 0;
+
+static void interruptCallback(void) {
+  if (interruptFlag != NULL && __atomic_load_n(interruptFlag, __ATOMIC_SEQ_CST)) {
+    GEOS_interruptRequest();
+  }
+  if (prevInterruptCallback != NULL) {
+    prevInterruptCallback();
+  }
+}
+
+void setInterruptFlag(GEOSContextHandle_t h, int *flag) {
+  if (!__atomic_exchange_n(&interruptInstalled, 1, __ATOMIC_SEQ_CST)) {
+    prevInterruptCallback = GEOS_interruptRegisterCallback(interruptCallback);
+  }
+  interruptFlag = flag;
+}
+
+#endif
+
+void raiseInterruptFlag(int *flag) {
+  __atomic_store_n(flag, 1, __ATOMIC_SEQ_CST);
+}
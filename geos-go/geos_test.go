@@ -27,6 +27,39 @@ func TestCoordSeqSize(t *testing.T) {
 	}
 }
 
+func TestCoordSeqXY(t *testing.T) {
+	h := NewHandle()
+	defer h.Destroy()
+
+	cs, err := NewCoordSeqFromXY(h, []float64{1, 2, 3, 4, 5, 6})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cs.Destroy(h)
+	if cs.Size(h) != 3 {
+		t.Fatalf("Expected 3 coords, got %d", cs.Size(h))
+	}
+	if cs.X(h, 1) != 3 || cs.Y(h, 1) != 4 {
+		t.Errorf("Expected (3, 4), got (%f, %f)", cs.X(h, 1), cs.Y(h, 1))
+	}
+
+	xy := make([]float64, 6)
+	if err := cs.CopyXY(h, xy); err != nil {
+		t.Fatal(err)
+	}
+	for i, exp := range []float64{1, 2, 3, 4, 5, 6} {
+		if xy[i] != exp {
+			t.Errorf("Expected %f at %d, got %f", exp, i, xy[i])
+		}
+	}
+	if err := cs.CopyXY(h, make([]float64, 4)); err != ErrIndexOutOfBounds {
+		t.Errorf("Expected ErrIndexOutOfBounds, got %v", err)
+	}
+	if _, err := NewCoordSeqFromXY(h, []float64{1, 2, 3}); err != ErrIndexOutOfBounds {
+		t.Errorf("Expected ErrIndexOutOfBounds, got %v", err)
+	}
+}
+
 func TestCoordSeqX(t *testing.T) {
 	h := NewHandle()
 	defer h.Destroy()
@@ -339,3 +372,113 @@ func TestNewMultipolygon(t *testing.T) {
 		t.Fatalf("expected 5 ('s, found %d", nCloses)
 	}
 }
+
+func makePoint(h *Handle, x, y float64) *Geometry {
+	cs := NewCoordSeq(h, 1, 2)
+	cs.SetX(h, 0, x)
+	cs.SetY(h, 0, y)
+	p, _ := cs.Point(h)
+	return p
+}
+
+func TestSTRtree(t *testing.T) {
+	h := NewHandle()
+	defer h.Destroy()
+
+	tree := NewSTRtree(h, 10)
+	defer tree.Destroy(h)
+
+	points := []*Geometry{
+		makePoint(h, 0, 0),
+		makePoint(h, 5, 5),
+		makePoint(h, 10, 10),
+	}
+	for i, p := range points {
+		defer p.Destroy(h)
+		tree.Insert(h, p, uint(i+1))
+	}
+
+	cs := makeTenByTenSquare(h)
+	shell, _ := cs.LinearRing(h)
+	query, _ := NewPolygon(h, shell, nil)
+	defer query.Destroy(h)
+
+	var found []uint
+	tree.Query(h, query, func(item uint) {
+		found = append(found, item)
+	})
+	if len(found) != 1 || found[0] != 3 {
+		t.Errorf("Expected to find item 3, got %v", found)
+	}
+
+	var all []uint
+	tree.Iterate(h, func(item uint) {
+		all = append(all, item)
+	})
+	if len(all) != 3 {
+		t.Errorf("Expected to iterate 3 items, got %v", all)
+	}
+
+	origin := makePoint(h, 4, 4)
+	defer origin.Destroy(h)
+	nearest, err := tree.Nearest(h, origin, func(item uint) (float64, error) {
+		return points[item-1].Distance(h, origin)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if nearest != 2 {
+		t.Errorf("Expected item 2 to be nearest, got %d", nearest)
+	}
+}
+
+func TestInterrupt(t *testing.T) {
+	h := NewHandle()
+	defer h.Destroy()
+
+	cs := makeTenByTenSquare(h)
+	shell, _ := cs.LinearRing(h)
+	poly, _ := NewPolygon(h, shell, nil)
+	defer poly.Destroy(h)
+
+	intr := NewInterrupt()
+	defer intr.Destroy()
+
+	var err error
+	intr.Run(h, func() {
+		var buf *Geometry
+		if buf, err = poly.Buffer(h, 1, 8); err == nil {
+			buf.Destroy(h)
+		}
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// An operation on another handle, running while the interrupt is raised
+	other := NewHandle()
+	defer other.Destroy()
+	otherPoly := poly.Clone(other)
+	defer otherPoly.Destroy(other)
+
+	intr.Request()
+	intr.Run(h, func() {
+		_, err = poly.Buffer(h, 1, 8)
+		otherBuf, otherErr := otherPoly.Buffer(other, 1, 8)
+		if otherErr != nil {
+			t.Errorf("Expected other handles to be unaffected, got %v", otherErr)
+		} else {
+			otherBuf.Destroy(other)
+		}
+	})
+	if err != ErrGeos {
+		t.Errorf("Expected %v, got %v", ErrGeos, err)
+	}
+
+	// Operations outside of Run are unaffected
+	buf, err := poly.Buffer(h, 1, 8)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	buf.Destroy(h)
+}
@@ -0,0 +1,135 @@
+package handle
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/vistarmedia/geom/geos-go"
+)
+
+var (
+	ErrExhausted = errors.New("All GEOS handles are leased")
+	ErrClosed    = errors.New("GEOS handle provider is closed")
+)
+
+// GeosHandleProvider which leases at most a fixed number of handles. Handles are
+// created on demand and, unlike PooledHandleProvider, are kept until Close is
+// called rather than being dropped by the GC. Get blocks while every handle is
+// leased; TryGet and GetContext can be used to fail instead.
+type BoundedHandleProvider struct {
+	idle     chan *geos.Handle
+	done     chan struct{}
+	capacity int
+	created  int
+	closed   bool
+	stats    *lifecycle
+	sync.Mutex
+}
+
+// A capacity below 1 is treated as 1.
+func NewBoundedHandleProvider(capacity int) *BoundedHandleProvider {
+	if capacity < 1 {
+		capacity = 1
+	}
+	return &BoundedHandleProvider{
+		idle:     make(chan *geos.Handle, capacity),
+		done:     make(chan struct{}),
+		capacity: capacity,
+		stats:    &lifecycle{},
+	}
+}
+
+// Leases a handle, blocking until one is available. Panics with ErrClosed if
+// the provider is closed.
+func (hp *BoundedHandleProvider) Get() *geos.Handle {
+	h, err := hp.GetContext(context.Background())
+	if err != nil {
+		panic(err)
+	}
+	return h
+}
+
+// Leases a handle, or returns ErrExhausted if none are available and
+// ErrClosed if the provider is closed.
+func (hp *BoundedHandleProvider) TryGet() (*geos.Handle, error) {
+	select {
+	case <-hp.done:
+		return nil, ErrClosed
+	default:
+	}
+	select {
+	case h := <-hp.idle:
+		return h, nil
+	default:
+	}
+	return hp.create()
+}
+
+// Leases a handle, blocking until one is available or ctx is done. Returns
+// ErrClosed if the provider is, or becomes, closed.
+func (hp *BoundedHandleProvider) GetContext(
+	ctx context.Context) (*geos.Handle, error) {
+
+	if h, err := hp.TryGet(); err != ErrExhausted {
+		return h, err
+	}
+	select {
+	case h := <-hp.idle:
+		return h, nil
+	case <-hp.done:
+		return nil, ErrClosed
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (hp *BoundedHandleProvider) Put(h *geos.Handle) {
+	hp.Lock()
+	defer hp.Unlock()
+	if hp.closed {
+		hp.stats.destroyHandle(h)
+		hp.created--
+		return
+	}
+	// Never blocks, as no more than capacity handles exist
+	hp.idle <- h
+}
+
+// Destroys every idle handle. Handles still leased are destroyed when they
+// are returned with Put. Leasing afterwards fails with ErrClosed, and callers
+// blocked waiting for a handle are woken with it.
+func (hp *BoundedHandleProvider) Close() {
+	hp.Lock()
+	defer hp.Unlock()
+	if !hp.closed {
+		hp.closed = true
+		close(hp.done)
+	}
+	for {
+		select {
+		case h := <-hp.idle:
+			hp.stats.destroyHandle(h)
+			hp.created--
+		default:
+			return
+		}
+	}
+}
+
+func (hp *BoundedHandleProvider) create() (*geos.Handle, error) {
+	hp.Lock()
+	defer hp.Unlock()
+	if hp.closed {
+		return nil, ErrClosed
+	}
+	if hp.created >= hp.capacity {
+		return nil, ErrExhausted
+	}
+	hp.created++
+	return hp.stats.newHandle(), nil
+}
+
+func (hp *BoundedHandleProvider) lifecycle() *lifecycle {
+	return hp.stats
+}
@@ -4,6 +4,7 @@ package handle
 import (
 	"runtime"
 	"sync"
+	"sync/atomic"
 
 	"github.com/vistarmedia/geom/geos-go"
 )
@@ -16,20 +17,62 @@ type GeosHandleProvider interface {
 	Put(*geos.Handle)
 }
 
+// Handle reserved for finalizers
+var finalizer struct {
+	h *geos.Handle
+	sync.Mutex
+}
+
+// Runs fn with a handle reserved for freeing GEOS objects from finalizers.
+// Finalizers must not lease from a provider, as a BoundedHandleProvider with
+// every handle leased would block the runtime's only finalizer goroutine and
+// stop every finalizer in the process.
+func Finalize(fn func(h *geos.Handle)) {
+	finalizer.Lock()
+	defer finalizer.Unlock()
+	if finalizer.h == nil {
+		finalizer.h = geos.NewHandle()
+	}
+	fn(finalizer.h)
+}
+
+// Counts handles created and destroyed by a provider
+type lifecycle struct {
+	created   int64
+	destroyed int64
+}
+
+func (l *lifecycle) newHandle() *geos.Handle {
+	atomic.AddInt64(&l.created, 1)
+	return geos.NewHandle()
+}
+
+func (l *lifecycle) destroyHandle(h *geos.Handle) {
+	atomic.AddInt64(&l.destroyed, 1)
+	h.Destroy()
+}
+
+// Implemented by providers in this package so InstrumentedHandleProvider can
+// report on handles they create and destroy
+type lifecycleProvider interface {
+	lifecycle() *lifecycle
+}
+
 // sync.Pool backed GeosHandleProvider
 type PooledHandleProvider struct {
-	pool *sync.Pool
+	pool  *sync.Pool
+	stats *lifecycle
 }
 
 func NewPooledHandleProvider() GeosHandleProvider {
+	stats := &lifecycle{}
 	return PooledHandleProvider{
 		&sync.Pool{New: func() interface{} {
-			h := geos.NewHandle()
-			runtime.SetFinalizer(h, func(h1 *geos.Handle) {
-				h1.Destroy()
-			})
+			h := stats.newHandle()
+			runtime.SetFinalizer(h, stats.destroyHandle)
 			return h
 		}},
+		stats,
 	}
 }
 
@@ -40,3 +83,7 @@ func (hp PooledHandleProvider) Get() *geos.Handle {
 func (hp PooledHandleProvider) Put(h *geos.Handle) {
 	hp.pool.Put(h)
 }
+
+func (hp PooledHandleProvider) lifecycle() *lifecycle {
+	return hp.stats
+}
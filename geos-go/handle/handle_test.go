@@ -0,0 +1,148 @@
+package handle
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestBoundedExhausted(t *testing.T) {
+	hp := NewBoundedHandleProvider(2)
+	defer hp.Close()
+
+	a := hp.Get()
+	b, err := hp.TryGet()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a == b {
+		t.Error("Expected distinct handles")
+	}
+	if _, err := hp.TryGet(); err != ErrExhausted {
+		t.Errorf("Expected ErrExhausted, got %v", err)
+	}
+
+	hp.Put(a)
+	c, err := hp.TryGet()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c != a {
+		t.Error("Expected returned handle to be reused")
+	}
+	hp.Put(b)
+	hp.Put(c)
+}
+
+func TestBoundedGetContext(t *testing.T) {
+	hp := NewBoundedHandleProvider(1)
+	defer hp.Close()
+
+	h := hp.Get()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := hp.GetContext(ctx); err != context.DeadlineExceeded {
+		t.Errorf("Expected DeadlineExceeded, got %v", err)
+	}
+
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		hp.Put(h)
+	}()
+	got, err := hp.GetContext(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != h {
+		t.Error("Expected the returned handle")
+	}
+	hp.Put(got)
+}
+
+func TestInstrumented(t *testing.T) {
+	bounded := NewBoundedHandleProvider(2)
+	ip := NewInstrumentedHandleProvider(bounded)
+	// Each reading of the clock advances it by a millisecond
+	clock := time.Unix(0, 0)
+	ip.now = func() time.Time {
+		clock = clock.Add(time.Millisecond)
+		return clock
+	}
+
+	a := ip.Get()
+	b := ip.Get()
+	ip.Put(a)
+	a = ip.Get()
+	ip.Put(a)
+	ip.Put(b)
+	bounded.Close()
+
+	stats := ip.Stats()
+	if stats.Gets != 3 || stats.Puts != 3 {
+		t.Errorf("Expected 3 gets and puts, got %d and %d", stats.Gets, stats.Puts)
+	}
+	if stats.Creations != 2 || stats.Destructions != 2 {
+		t.Errorf("Expected 2 creations and destructions, got %d and %d",
+			stats.Creations, stats.Destructions)
+	}
+	if stats.WaitTime != 3*time.Millisecond {
+		t.Errorf("Expected 3ms wait time, got %v", stats.WaitTime)
+	}
+}
+
+func TestBoundedCapacity(t *testing.T) {
+	hp := NewBoundedHandleProvider(0)
+	defer hp.Close()
+	h, err := hp.TryGet()
+	if err != nil {
+		t.Fatalf("Expected a capacity of 1, got %v", err)
+	}
+	hp.Put(h)
+}
+
+func TestBoundedCloseWhileLeased(t *testing.T) {
+	hp := NewBoundedHandleProvider(2)
+	a := hp.Get()
+	b := hp.Get()
+	hp.Put(b)
+
+	// Destroys the idle handle without waiting for the leased one
+	hp.Close()
+	if d := hp.stats.destroyed; d != 1 {
+		t.Errorf("Expected 1 handle destroyed, got %d", d)
+	}
+	hp.Put(a)
+	if d := hp.stats.destroyed; d != 2 {
+		t.Errorf("Expected the returned handle to be destroyed, got %d", d)
+	}
+}
+
+func TestBoundedGetAfterClose(t *testing.T) {
+	hp := NewBoundedHandleProvider(1)
+	h := hp.Get()
+
+	errs := make(chan error)
+	go func() {
+		_, err := hp.GetContext(context.Background())
+		errs <- err
+	}()
+	time.Sleep(10 * time.Millisecond)
+	hp.Close()
+	if err := <-errs; err != ErrClosed {
+		t.Errorf("Expected a blocked lease to fail with ErrClosed, got %v", err)
+	}
+	hp.Put(h)
+
+	if _, err := hp.TryGet(); err != ErrClosed {
+		t.Errorf("Expected ErrClosed, got %v", err)
+	}
+	if _, err := hp.GetContext(context.Background()); err != ErrClosed {
+		t.Errorf("Expected ErrClosed, got %v", err)
+	}
+	defer func() {
+		if r := recover(); r != ErrClosed {
+			t.Errorf("Expected Get to panic with ErrClosed, got %v", r)
+		}
+	}()
+	hp.Get()
+}
@@ -0,0 +1,69 @@
+package handle
+
+import (
+	"sync/atomic"
+	"time"
+
+	"github.com/vistarmedia/geom/geos-go"
+)
+
+type HandleStats struct {
+	Gets int64
+	Puts int64
+	// Handles created and destroyed by the wrapped provider. Always zero for
+	// providers outside of this package.
+	Creations    int64
+	Destructions int64
+	// Total time spent waiting in Get
+	WaitTime time.Duration
+}
+
+// Wraps a GeosHandleProvider, counting leases and time spent waiting for them.
+type InstrumentedHandleProvider struct {
+	hp       GeosHandleProvider
+	gets     int64
+	puts     int64
+	waitTime int64
+	// Replaced in tests
+	now func() time.Time
+}
+
+func NewInstrumentedHandleProvider(
+	hp GeosHandleProvider) *InstrumentedHandleProvider {
+
+	return &InstrumentedHandleProvider{hp: hp, now: time.Now}
+}
+
+func (ip *InstrumentedHandleProvider) Get() *geos.Handle {
+	start := ip.now()
+	h := ip.hp.Get()
+	atomic.AddInt64(&ip.waitTime, int64(ip.now().Sub(start)))
+	atomic.AddInt64(&ip.gets, 1)
+	return h
+}
+
+func (ip *InstrumentedHandleProvider) Put(h *geos.Handle) {
+	atomic.AddInt64(&ip.puts, 1)
+	ip.hp.Put(h)
+}
+
+// The wrapped provider
+func (ip *InstrumentedHandleProvider) Unwrap() GeosHandleProvider {
+	return ip.hp
+}
+
+// Snapshot of the provider's counters. Gets minus Puts is the number of
+// handles currently leased.
+func (ip *InstrumentedHandleProvider) Stats() HandleStats {
+	stats := HandleStats{
+		Gets:     atomic.LoadInt64(&ip.gets),
+		Puts:     atomic.LoadInt64(&ip.puts),
+		WaitTime: time.Duration(atomic.LoadInt64(&ip.waitTime)),
+	}
+	if lp, ok := ip.hp.(lifecycleProvider); ok {
+		l := lp.lifecycle()
+		stats.Creations = atomic.LoadInt64(&l.created)
+		stats.Destructions = atomic.LoadInt64(&l.destroyed)
+	}
+	return stats
+}
@@ -0,0 +1,49 @@
+package geos
+
+// #include <stdlib.h>
+// #include <geos_c.h>
+// extern void setInterruptFlag(GEOSContextHandle_t, int*);
+// extern void raiseInterruptFlag(int*);
+import "C"
+
+import (
+	"runtime"
+	"unsafe"
+)
+
+// Aborts GEOS operations from another goroutine. Operations run with Run will
+// fail with ErrGeos shortly after Request is called. Only the operation in Run
+// is affected, so operations on other handles keep running. libgeos 3.14 and
+// later interrupt the handle itself; older versions fall back to the global
+// interrupt callback with a thread local flag.
+type Interrupt struct {
+	flag *C.int
+}
+
+func NewInterrupt() *Interrupt {
+	flag := (*C.int)(C.malloc(C.sizeof_int))
+	*flag = 0
+	return &Interrupt{flag}
+}
+
+// Must not be called while Run is in progress.
+func (i *Interrupt) Destroy() {
+	C.free(unsafe.Pointer(i.flag))
+}
+
+// Aborts the operation in Run. Safe to call from any goroutine.
+func (i *Interrupt) Request() {
+	C.raiseInterruptFlag(i.flag)
+}
+
+// Runs fn, which may be aborted by Request if it only uses h. Any other
+// interrupt callback on h is replaced while fn runs.
+func (i *Interrupt) Run(h *Handle, fn func()) {
+	// Before libgeos 3.14 the flag is thread local, so the goroutine must not
+	// migrate threads while fn runs.
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+	C.setInterruptFlag(h.h, i.flag)
+	defer C.setInterruptFlag(h.h, nil)
+	fn()
+}
@@ -0,0 +1,113 @@
+package geos
+
+// #include <stdint.h>
+// #include <geos_c.h>
+// extern void strtreeInsert(GEOSContextHandle_t, GEOSSTRtree*,
+//   const GEOSGeometry*, size_t);
+// extern void strtreeQuery(GEOSContextHandle_t, GEOSSTRtree*,
+//   const GEOSGeometry*, uintptr_t);
+// extern void strtreeIterate(GEOSContextHandle_t, GEOSSTRtree*, uintptr_t);
+// extern size_t strtreeNearest(GEOSContextHandle_t, GEOSSTRtree*,
+//   const GEOSGeometry*, uintptr_t);
+import "C"
+
+import (
+	"runtime/cgo"
+)
+
+// http://geos.osgeo.org/doxygen/classgeos_1_1index_1_1strtree_1_1STRtree.html
+// Not thread safe.
+//
+// Items are identified by non-zero integers rather than pointers, as Go
+// pointers can not be stored in C memory. Callers are responsible for mapping
+// items back to their values and for keeping inserted geometries alive for
+// the lifetime of the tree.
+type STRtree struct {
+	t *C.GEOSSTRtree
+}
+
+func NewSTRtree(h *Handle, nodeCapacity uint) *STRtree {
+	return &STRtree{C.GEOSSTRtree_create_r(h.h, C.size_t(nodeCapacity))}
+}
+
+func (t *STRtree) Destroy(h *Handle) {
+	C.GEOSSTRtree_destroy_r(h.h, t.t)
+}
+
+// Indexes the envelope of g under item, which must not be 0. The tree can not
+// be inserted in to once it has been built or queried.
+func (t *STRtree) Insert(h *Handle, g *Geometry, item uint) {
+	C.strtreeInsert(h.h, t.t, g.g, C.size_t(item))
+}
+
+// Builds the tree. Otherwise, the tree is built on the first query.
+func (t *STRtree) Build(h *Handle) error {
+	if C.GEOSSTRtree_build_r(h.h, t.t) == 0 {
+		return ErrGeos
+	}
+	return nil
+}
+
+// Calls fn with every item whose envelope intersects the envelope of g.
+func (t *STRtree) Query(h *Handle, g *Geometry, fn func(item uint)) {
+	userdata := cgo.NewHandle(fn)
+	defer userdata.Delete()
+	C.strtreeQuery(h.h, t.t, g.g, C.uintptr_t(userdata))
+}
+
+// Calls fn with every item in the tree.
+func (t *STRtree) Iterate(h *Handle, fn func(item uint)) {
+	userdata := cgo.NewHandle(fn)
+	defer userdata.Delete()
+	C.strtreeIterate(h.h, t.t, C.uintptr_t(userdata))
+}
+
+// Finds the item nearest to g, as measured by the distance function. Returns
+// 0 if the tree is empty.
+func (t *STRtree) Nearest(h *Handle, g *Geometry,
+	distance func(item uint) (float64, error)) (uint, error) {
+
+	var distErr error
+	fn := func(item uint) (float64, bool) {
+		d, err := distance(item)
+		if err != nil {
+			distErr = err
+			return 0, false
+		}
+		return d, true
+	}
+	userdata := cgo.NewHandle(fn)
+	defer userdata.Delete()
+
+	item := uint(C.strtreeNearest(h.h, t.t, g.g, C.uintptr_t(userdata)))
+	if distErr != nil {
+		return 0, distErr
+	}
+	return item, nil
+}
+
+//export goSTRtreeItemCallback
+func goSTRtreeItemCallback(item C.size_t, userdata C.uintptr_t) {
+	fn := cgo.Handle(userdata).Value().(func(uint))
+	fn(uint(item))
+}
+
+// The query geometry is passed to GEOS as item 0, so only one of item1 and
+// item2 refers to an item in the tree.
+//
+//export goSTRtreeDistanceCallback
+func goSTRtreeDistanceCallback(
+	item1, item2 C.size_t, distance *C.double, userdata C.uintptr_t) C.int {
+
+	fn := cgo.Handle(userdata).Value().(func(uint) (float64, bool))
+	item := item1
+	if item == 0 {
+		item = item2
+	}
+	d, ok := fn(uint(item))
+	if !ok {
+		return 0
+	}
+	*distance = C.double(d)
+	return 1
+}
@@ -0,0 +1,205 @@
+// Package join implements spatial joins between slices of geometries.
+//
+// The right side of a join is indexed by bounding box in an rtree.RTree.
+// Candidates from the index are refined with prepared geometry predicates, so
+// each right geometry is only prepared once no matter how many left geometries
+// are tested against it. Left geometries are tested in parallel and matches
+// are streamed back as they are found, in no particular order. A prepared
+// geometry serializes its predicates, so workers probing the same right
+// geometry take turns; parallelism comes from probing different ones.
+package join
+
+import (
+	"context"
+	"runtime"
+	"sync"
+
+	"github.com/vistarmedia/geom"
+	"github.com/vistarmedia/geom/rtree"
+)
+
+// Exact test applied to candidate pairs whose bounding boxes intersect.
+// Evaluated as right.Predicate(left).
+type Predicate func(right *geom.PreparedGeometry, left *geom.Geometry) (bool, error)
+
+var (
+	// Right covers left, including left lying on right's boundary
+	Covers Predicate = func(r *geom.PreparedGeometry, l *geom.Geometry) (bool, error) {
+		return r.Covers(l)
+	}
+	// Left lies in right's interior
+	Contains Predicate = func(r *geom.PreparedGeometry, l *geom.Geometry) (bool, error) {
+		return r.Contains(l)
+	}
+	Intersects Predicate = func(r *geom.PreparedGeometry, l *geom.Geometry) (bool, error) {
+		return r.Intersects(l)
+	}
+	Overlaps Predicate = func(r *geom.PreparedGeometry, l *geom.Geometry) (bool, error) {
+		return r.Overlaps(l)
+	}
+)
+
+type Options struct {
+	// Defaults to Covers for PointInPolygonJoin and Intersects for
+	// PolygonOverlapJoin
+	Predicate Predicate
+	// Number of goroutines testing left geometries. Defaults to GOMAXPROCS.
+	Workers int
+	// Size of the result channel buffer
+	Buffer int
+}
+
+// A matching pair of indices in to the left and right slices. If Err is set,
+// the join failed and no more matches will be sent.
+type Match struct {
+	Left  int
+	Right int
+	Err   error
+}
+
+// Finds every polygon which covers each point. Left indices refer to points and
+// right indices to polygons. The channel is closed once the join completes or
+// ctx is done; check ctx.Err() to tell the two apart. A reader may stop reading
+// once ctx is done.
+func PointInPolygonJoin(ctx context.Context,
+	points, polygons []*geom.Geometry, opts Options) <-chan Match {
+
+	if opts.Predicate == nil {
+		opts.Predicate = Covers
+	}
+	return join(ctx, points, polygons, opts)
+}
+
+// Finds every right polygon which intersects each left polygon. To find the
+// overlapping polygons within a single slice, pass it as both left and right;
+// a geometry is never matched with itself, but each overlapping pair is
+// matched in both directions. The channel is closed once the join completes or
+// ctx is done, as with PointInPolygonJoin.
+func PolygonOverlapJoin(ctx context.Context,
+	left, right []*geom.Geometry, opts Options) <-chan Match {
+
+	if opts.Predicate == nil {
+		opts.Predicate = Intersects
+	}
+	return join(ctx, left, right, opts)
+}
+
+// Reads every match from a join started with ctx. Returns the first error
+// encountered, or ctx.Err() if the join was cancelled.
+func Collect(ctx context.Context, matches <-chan Match) ([]Match, error) {
+	var all []Match
+	for m := range matches {
+		if m.Err != nil {
+			return nil, m.Err
+		}
+		all = append(all, m)
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return all, nil
+}
+
+func join(parent context.Context,
+	left, right []*geom.Geometry, opts Options) <-chan Match {
+
+	workers := opts.Workers
+	if workers < 1 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	out := make(chan Match, opts.Buffer)
+	ctx, cancel := context.WithCancel(parent)
+
+	var (
+		errOnce sync.Once
+		joinErr error
+	)
+	fail := func(err error) {
+		errOnce.Do(func() {
+			joinErr = err
+			cancel()
+		})
+	}
+	send := func(m Match) bool {
+		if ctx.Err() != nil {
+			return false
+		}
+		select {
+		case out <- m:
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}
+
+	go func() {
+		defer close(out)
+		defer cancel()
+
+		index := rtree.New(rtree.DefaultMaxEntries)
+		for i, g := range right {
+			if _, err := index.Insert(g, i); err != nil {
+				fail(err)
+				break
+			}
+		}
+
+		lefts := make(chan int)
+		var wg sync.WaitGroup
+		for w := 0; w < workers; w++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for i := range lefts {
+					if err := probe(index, i, left[i], opts.Predicate, send); err != nil {
+						fail(err)
+					}
+				}
+			}()
+		}
+
+	feed:
+		for i := range left {
+			select {
+			case lefts <- i:
+			case <-ctx.Done():
+				break feed
+			}
+		}
+		close(lefts)
+		wg.Wait()
+
+		// Every worker has finished, so the error is always the last message
+		if joinErr != nil {
+			select {
+			case out <- Match{Err: joinErr}:
+			case <-parent.Done():
+			}
+		}
+	}()
+
+	return out
+}
+
+// Sends a match for every right geometry matching g
+func probe(index *rtree.RTree, i int, g *geom.Geometry,
+	pred Predicate, send func(Match) bool) error {
+
+	r, err := rtree.Bounds(g)
+	if err != nil {
+		return err
+	}
+	for _, item := range index.Search(r) {
+		if item.Geometry == g {
+			continue
+		}
+		ok, err := pred(item.Prepared(), g)
+		if err != nil {
+			return err
+		}
+		if ok && !send(Match{Left: i, Right: item.Value.(int)}) {
+			return nil
+		}
+	}
+	return nil
+}
@@ -0,0 +1,175 @@
+package join
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	"github.com/vistarmedia/geom"
+	"github.com/vistarmedia/geom/geos-go/handle"
+)
+
+var fact = geom.NewFactory(handle.NewPooledHandleProvider())
+
+func square(t *testing.T, x, y, size float64) *geom.Geometry {
+	poly, err := fact.NewPolygon([]geom.Coord{
+		{X: x, Y: y},
+		{X: x + size, Y: y},
+		{X: x + size, Y: y + size},
+		{X: x, Y: y + size},
+		{X: x, Y: y},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return poly.Geometry
+}
+
+func point(t *testing.T, x, y float64) *geom.Geometry {
+	p, err := fact.NewPoint(geom.Coord{X: x, Y: y})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return p.Geometry
+}
+
+func sortMatches(ms []Match) {
+	sort.Slice(ms, func(i, j int) bool {
+		if ms[i].Left == ms[j].Left {
+			return ms[i].Right < ms[j].Right
+		}
+		return ms[i].Left < ms[j].Left
+	})
+}
+
+func compareMatches(t *testing.T, exp, act []Match) {
+	t.Helper()
+	sortMatches(exp)
+	sortMatches(act)
+	if len(exp) != len(act) {
+		t.Fatalf("Expected %v, got %v", exp, act)
+	}
+	for i := range exp {
+		if exp[i] != act[i] {
+			t.Fatalf("Expected %v, got %v", exp, act)
+		}
+	}
+}
+
+func TestPointInPolygonJoin(t *testing.T) {
+	ctx := context.Background()
+	zones := []*geom.Geometry{
+		square(t, 0, 0, 10),
+		square(t, 5, 5, 10),
+		square(t, 20, 20, 5),
+	}
+	points := []*geom.Geometry{
+		point(t, 1, 1),   // zone 0
+		point(t, 7, 7),   // zones 0 and 1
+		point(t, 10, 10), // boundary of 0, inside 1
+		point(t, 18, 18), // nothing
+		point(t, 22, 22), // zone 2
+	}
+
+	for _, workers := range []int{1, 4} {
+		matches, err := Collect(ctx, PointInPolygonJoin(
+			ctx, points, zones, Options{Workers: workers}))
+		if err != nil {
+			t.Fatal(err)
+		}
+		compareMatches(t, []Match{
+			{Left: 0, Right: 0},
+			{Left: 1, Right: 0},
+			{Left: 1, Right: 1},
+			{Left: 2, Right: 0},
+			{Left: 2, Right: 1},
+			{Left: 4, Right: 2},
+		}, matches)
+	}
+
+	// Boundary points are excluded with Contains
+	matches, err := Collect(ctx, PointInPolygonJoin(
+		ctx, points, zones, Options{Predicate: Contains}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	compareMatches(t, []Match{
+		{Left: 0, Right: 0},
+		{Left: 1, Right: 0},
+		{Left: 1, Right: 1},
+		{Left: 2, Right: 1},
+		{Left: 4, Right: 2},
+	}, matches)
+}
+
+func TestPolygonOverlapSelfJoin(t *testing.T) {
+	ctx := context.Background()
+	polys := []*geom.Geometry{
+		square(t, 0, 0, 10),
+		square(t, 5, 5, 10),
+		square(t, 10, 4, 2), // touches 0, overlaps 1
+		square(t, 50, 50, 1),
+	}
+
+	matches, err := Collect(ctx, PolygonOverlapJoin(
+		ctx, polys, polys, Options{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	compareMatches(t, []Match{
+		{Left: 0, Right: 1},
+		{Left: 0, Right: 2},
+		{Left: 1, Right: 0},
+		{Left: 1, Right: 2},
+		{Left: 2, Right: 0},
+		{Left: 2, Right: 1},
+	}, matches)
+
+	matches, err = Collect(ctx, PolygonOverlapJoin(
+		ctx, polys, polys, Options{Predicate: Overlaps}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	compareMatches(t, []Match{
+		{Left: 0, Right: 1},
+		{Left: 1, Right: 0},
+		{Left: 1, Right: 2},
+		{Left: 2, Right: 1},
+	}, matches)
+}
+
+func TestJoinCancel(t *testing.T) {
+	zones := []*geom.Geometry{square(t, 0, 0, 10)}
+	var points []*geom.Geometry
+	for i := 0; i < 100; i++ {
+		points = append(points, point(t, 5, 5))
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	matches := PointInPolygonJoin(ctx, points, zones, Options{Workers: 2})
+	<-matches
+	cancel()
+
+	n := 1
+	for m := range matches {
+		if m.Err != nil {
+			t.Fatal(m.Err)
+		}
+		n++
+	}
+	if n == len(points) {
+		t.Errorf("Expected join to stop early")
+	}
+}
+
+func TestCollectCancelled(t *testing.T) {
+	zones := []*geom.Geometry{square(t, 0, 0, 10)}
+	points := []*geom.Geometry{point(t, 5, 5)}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := Collect(ctx, PointInPolygonJoin(ctx, points, zones, Options{}))
+	if err != context.Canceled {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+}
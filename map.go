@@ -0,0 +1,193 @@
+package geom
+
+import (
+	"math"
+	"runtime"
+
+	"github.com/vistarmedia/geom/geos-go"
+)
+
+// Builds a new geometry of the same type and structure, with fn applied to
+// every coordinate. Holes and collection members, including those of
+// MULTIPOINT and MULTILINESTRING collections, are preserved, as is the SRID.
+func (g *Geometry) Map(fn func(Coord) Coord) (*Geometry, error) {
+	return g.MapErr(func(c Coord) (Coord, error) {
+		return fn(c), nil
+	})
+}
+
+// Like Map, but stops at the first error returned by fn
+func (g *Geometry) MapErr(fn func(Coord) (Coord, error)) (*Geometry, error) {
+	return g.Factory(WithSRID(g.SRID())).MapErr(g, fn)
+}
+
+// Like Geometry.MapErr, but the result is built with this factory's precision
+// and SRID in place of g's SRID
+func (f Factory) MapErr(g *Geometry,
+	fn func(Coord) (Coord, error)) (*Geometry, error) {
+
+	h := f.hp.Get()
+	defer f.hp.Put(h)
+
+	mapped, err := mapGeos(h, g.g, fn)
+	runtime.KeepAlive(g)
+	return f.newGeometryOrError(h, mapped, err)
+}
+
+// Coefficients of the affine transform
+//
+//	x' = A*x + B*y + XOff
+//	y' = D*x + E*y + YOff
+type AffineTransform struct {
+	A, B, D, E float64
+	XOff, YOff float64
+}
+
+func (t AffineTransform) Apply(c Coord) Coord {
+	return Coord{
+		X: t.A*c.X + t.B*c.Y + t.XOff,
+		Y: t.D*c.X + t.E*c.Y + t.YOff,
+	}
+}
+
+// Transform applying t and then o
+func (t AffineTransform) Then(o AffineTransform) AffineTransform {
+	return AffineTransform{
+		A:    o.A*t.A + o.B*t.D,
+		B:    o.A*t.B + o.B*t.E,
+		D:    o.D*t.A + o.E*t.D,
+		E:    o.D*t.B + o.E*t.E,
+		XOff: o.A*t.XOff + o.B*t.YOff + o.XOff,
+		YOff: o.D*t.XOff + o.E*t.YOff + o.YOff,
+	}
+}
+
+func (g *Geometry) Affine(t AffineTransform) (*Geometry, error) {
+	return g.Map(t.Apply)
+}
+
+func (g *Geometry) Translate(dx, dy float64) (*Geometry, error) {
+	return g.Affine(AffineTransform{A: 1, E: 1, XOff: dx, YOff: dy})
+}
+
+// Scales by sx and sy relative to origin
+func (g *Geometry) Scale(sx, sy float64, origin Coord) (*Geometry, error) {
+	return g.Affine(AffineTransform{
+		A:    sx,
+		E:    sy,
+		XOff: origin.X - sx*origin.X,
+		YOff: origin.Y - sy*origin.Y,
+	})
+}
+
+// Rotates counter-clockwise by angle radians about origin
+func (g *Geometry) Rotate(angle float64, origin Coord) (*Geometry, error) {
+	sin, cos := math.Sincos(angle)
+	return g.Affine(AffineTransform{
+		A:    cos,
+		B:    -sin,
+		D:    sin,
+		E:    cos,
+		XOff: origin.X - cos*origin.X + sin*origin.Y,
+		YOff: origin.Y - sin*origin.X - cos*origin.Y,
+	})
+}
+
+func mapGeos(h *geos.Handle, g *geos.Geometry,
+	fn func(Coord) (Coord, error)) (*geos.Geometry, error) {
+
+	if isEmpty, err := g.IsEmpty(h); err != nil {
+		return nil, err
+	} else if isEmpty {
+		return g.Clone(h), nil
+	}
+
+	switch typeId := g.TypeId(h); typeId {
+	case geos.POINT, geos.LINESTRING, geos.LINEARRING:
+		coords, err := geosCoords(h, g)
+		if err != nil {
+			return nil, err
+		}
+		for i, c := range coords {
+			if coords[i], err = fn(c); err != nil {
+				return nil, err
+			}
+		}
+		cs, err := newGeosCoordSeq(h, coords)
+		if err != nil {
+			return nil, err
+		}
+		switch typeId {
+		case geos.POINT:
+			return cs.Point(h)
+		case geos.LINESTRING:
+			return cs.LineString(h)
+		default:
+			return cs.LinearRing(h)
+		}
+
+	case geos.POLYGON:
+		ring, err := g.ExteriorRing(h)
+		if err != nil {
+			return nil, err
+		}
+		shell, err := mapGeos(h, ring, fn)
+		if err != nil {
+			return nil, err
+		}
+		numHoles, err := g.NumInteriorRings(h)
+		if err != nil {
+			shell.Destroy(h)
+			return nil, err
+		}
+		holes := make([]*geos.Geometry, 0, numHoles)
+		destroy := func() {
+			shell.Destroy(h)
+			for _, hole := range holes {
+				hole.Destroy(h)
+			}
+		}
+		for i := 0; i < numHoles; i++ {
+			ring, err := g.InteriorRingN(h, i)
+			if err != nil {
+				destroy()
+				return nil, err
+			}
+			hole, err := mapGeos(h, ring, fn)
+			if err != nil {
+				destroy()
+				return nil, err
+			}
+			holes = append(holes, hole)
+		}
+		// The polygon takes ownership of the rings, even on error
+		return geos.NewPolygon(h, shell, holes)
+
+	default:
+		n, err := g.NumGeometries(h)
+		if err != nil {
+			return nil, err
+		}
+		parts := make([]*geos.Geometry, 0, n)
+		destroy := func() {
+			for _, part := range parts {
+				part.Destroy(h)
+			}
+		}
+		for i := 0; i < n; i++ {
+			owned, err := g.GeometryN(h, i)
+			if err != nil {
+				destroy()
+				return nil, err
+			}
+			part, err := mapGeos(h, owned, fn)
+			if err != nil {
+				destroy()
+				return nil, err
+			}
+			parts = append(parts, part)
+		}
+		// The collection takes ownership of the parts, even on error
+		return geos.NewGeometryCollection(h, typeId, parts)
+	}
+}
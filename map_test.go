@@ -0,0 +1,163 @@
+package geom
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func assertCoordsNear(t *testing.T, exp, act []Coord) {
+	t.Helper()
+	if len(exp) != len(act) {
+		t.Fatalf("Expected %v, got %v", exp, act)
+	}
+	for i := range exp {
+		if math.Abs(exp[i].X-act[i].X) > 1e-9 || math.Abs(exp[i].Y-act[i].Y) > 1e-9 {
+			t.Fatalf("Expected %v, got %v", exp, act)
+		}
+	}
+}
+
+func TestMapPolygon(t *testing.T) {
+	f := NewFactory(fact.hp, WithSRID(3857))
+	poly, err := f.NewPolygon(
+		[]Coord{{0, 0}, {10, 0}, {10, 10}, {0, 10}, {0, 0}},
+		[]Coord{{2, 2}, {2, 4}, {4, 4}, {4, 2}, {2, 2}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	moved, err := poly.Translate(5, -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if moved.Type() != POLYGON {
+		t.Fatalf("Expected POLYGON, got %d", moved.Type())
+	}
+	if moved.SRID() != 3857 {
+		t.Errorf("Expected SRID to be preserved, got %d", moved.SRID())
+	}
+	shell, err := moved.Polygon().Shell()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertCoordsNear(t,
+		[]Coord{{5, -1}, {15, -1}, {15, 9}, {5, 9}, {5, -1}}, shell)
+	holes, err := moved.Polygon().Holes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(holes) != 1 {
+		t.Fatalf("Expected 1 hole, got %d", len(holes))
+	}
+	assertCoordsNear(t,
+		[]Coord{{7, 1}, {7, 3}, {9, 3}, {9, 1}, {7, 1}}, holes[0])
+}
+
+func TestMapCollection(t *testing.T) {
+	p, err := fact.NewPoint(Coord{1, 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ls, err := fact.NewLineString([]Coord{{0, 0}, {1, 1}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	empty := fact.NewEmptyPolygon()
+	coll, err := fact.NewGeometryCollection(p.Geometry, ls.Geometry, empty.Geometry)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	scaled, err := coll.Scale(2, 3, Coord{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if scaled.Type() != GEOMETRYCOLLECTION {
+		t.Fatalf("Expected GEOMETRYCOLLECTION, got %d", scaled.Type())
+	}
+	gs, err := scaled.Geometries()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(gs) != 3 {
+		t.Fatalf("Expected 3 geometries, got %d", len(gs))
+	}
+	c, err := gs[0].Point().Coord()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertCoordsNear(t, []Coord{{2, 6}}, []Coord{c})
+	coords, err := gs[1].LineString().Coords()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertCoordsNear(t, []Coord{{0, 0}, {2, 3}}, coords)
+	if isEmpty, _ := gs[2].IsEmpty(); !isEmpty || gs[2].Type() != POLYGON {
+		t.Error("Expected an empty polygon")
+	}
+}
+
+func TestMapErr(t *testing.T) {
+	poly, err := fact.NewPolygon(
+		[]Coord{{0, 0}, {10, 0}, {10, 10}, {0, 10}, {0, 0}},
+		[]Coord{{2, 2}, {2, 4}, {4, 4}, {4, 2}, {2, 2}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	errFar := errors.New("far")
+	_, err = poly.MapErr(func(c Coord) (Coord, error) {
+		if c.X > 3 {
+			return c, errFar
+		}
+		return c, nil
+	})
+	if err != errFar {
+		t.Errorf("Expected %v, got %v", errFar, err)
+	}
+
+	f := NewFactory(fact.hp, WithPrecision(1), WithSRID(4326))
+	quarter, err := f.MapErr(poly.Geometry, func(c Coord) (Coord, error) {
+		return Coord{X: c.X / 4, Y: c.Y / 4}, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if quarter.SRID() != 4326 {
+		t.Errorf("Expected SRID 4326, got %d", quarter.SRID())
+	}
+	shell, err := quarter.Polygon().Shell()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, c := range shell {
+		if c.X != math.Round(c.X) || c.Y != math.Round(c.Y) {
+			t.Fatalf("Expected snapped coordinates, got %v", shell)
+		}
+	}
+}
+
+func TestRotate(t *testing.T) {
+	ls, err := fact.NewLineString([]Coord{{1, 1}, {2, 1}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	rotated, err := ls.Rotate(math.Pi/2, Coord{1, 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	coords, err := rotated.LineString().Coords()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertCoordsNear(t, []Coord{{1, 1}, {1, 2}}, coords)
+}
+
+func TestAffineThen(t *testing.T) {
+	translate := AffineTransform{A: 1, E: 1, XOff: 1, YOff: 2}
+	scale := AffineTransform{A: 2, E: 3}
+	c := Coord{4, 5}
+	exp := scale.Apply(translate.Apply(c))
+	if act := translate.Then(scale).Apply(c); act != exp {
+		t.Errorf("Expected %v, got %v", exp, act)
+	}
+}
@@ -0,0 +1,220 @@
+package geom
+
+import (
+	"runtime"
+
+	"github.com/vistarmedia/geom/geos-go"
+	"github.com/vistarmedia/geom/geos-go/handle"
+)
+
+// Geometry operations sharing a single leased handle. Only valid for the
+// duration of the WithHandle callback which received it, and must not be used
+// from other goroutines.
+type Ops struct {
+	hp handle.GeosHandleProvider
+	h  *geos.Handle
+}
+
+// Leases one handle from hp and uses it for every operation fn makes through
+// ops, rather than leasing a handle per call. Geometries from any provider may
+// be passed to ops. fn must not call methods on Geometry directly, as they
+// lease their own handle and will block on an exhausted bounded provider.
+func WithHandle(hp handle.GeosHandleProvider, fn func(ops Ops) error) error {
+	h := hp.Get()
+	defer hp.Put(h)
+	return fn(Ops{hp, h})
+}
+
+// WithHandle using the factory's handle provider
+func (f Factory) WithHandle(fn func(ops Ops) error) error {
+	return WithHandle(f.hp, fn)
+}
+
+func (ops Ops) Type(g *Geometry) GeometryType {
+	defer runtime.KeepAlive(g)
+	return geometryType(g.g.TypeId(ops.h))
+}
+
+func (ops Ops) Area(g *Geometry) float64 {
+	defer runtime.KeepAlive(g)
+	return g.g.Area(ops.h)
+}
+
+func (ops Ops) IsEmpty(g *Geometry) (bool, error) {
+	defer runtime.KeepAlive(g)
+	return g.g.IsEmpty(ops.h)
+}
+
+func (ops Ops) NumGeometries(g *Geometry) (int, error) {
+	defer runtime.KeepAlive(g)
+	return g.g.NumGeometries(ops.h)
+}
+
+// See Geometry.Extent
+func (ops Ops) Extent(g *Geometry) (Envelope, error) {
+	defer runtime.KeepAlive(g)
+	if isEmpty, err := g.g.IsEmpty(ops.h); err != nil {
+		return Envelope{}, err
+	} else if isEmpty {
+		return Envelope{}, ErrEmptyGeometry
+	}
+	xmin, ymin, xmax, ymax, err := g.g.Extent(ops.h)
+	if err != nil {
+		return Envelope{}, err
+	}
+	return Envelope{xmin, ymin, xmax, ymax}, nil
+}
+
+// See Geometry.Bounds
+func (ops Ops) Bounds(g *Geometry) (c0 Coord, c1 Coord, err error) {
+	env, err := ops.Extent(g)
+	if err != nil {
+		return
+	}
+	return env.Min(), env.Max(), nil
+}
+
+func (ops Ops) Distance(g *Geometry, o toGeos) (float64, error) {
+	defer runtime.KeepAlive(g)
+	defer runtime.KeepAlive(o)
+	return g.g.Distance(ops.h, o.UnsafeToGeos())
+}
+
+// Coordinates of a Point, LineString or LinearRing
+func (ops Ops) Coords(g *Geometry) ([]Coord, error) {
+	defer runtime.KeepAlive(g)
+	return ops.coords(g.g)
+}
+
+// See Polygon.Shell
+func (ops Ops) Shell(p Polygon) ([]Coord, error) {
+	defer runtime.KeepAlive(p.Geometry)
+	shell, err := p.g.ExteriorRing(ops.h)
+	if err != nil {
+		return nil, err
+	}
+	// The polygon owns the shell, so it's copied out rather than returned as a
+	// LinearRing
+	return ops.coords(shell)
+}
+
+// See Polygon.Holes
+func (ops Ops) Holes(p Polygon) (coords [][]Coord, err error) {
+	defer runtime.KeepAlive(p.Geometry)
+	numRings, err := p.g.NumInteriorRings(ops.h)
+	if err != nil {
+		return
+	}
+	for ringIdx := 0; ringIdx < numRings; ringIdx++ {
+		var ring *geos.Geometry
+		ring, err = p.g.InteriorRingN(ops.h, ringIdx)
+		if err != nil {
+			return
+		}
+		var ringCoords []Coord
+		ringCoords, err = ops.coords(ring)
+		if err != nil {
+			return
+		}
+		coords = append(coords, ringCoords)
+	}
+	return
+}
+
+func (ops Ops) Intersects(g *Geometry, o toGeos) (bool, error) {
+	return ops.predicate(g, g.g.Intersects, o)
+}
+
+func (ops Ops) Contains(g *Geometry, o toGeos) (bool, error) {
+	return ops.predicate(g, g.g.Contains, o)
+}
+
+func (ops Ops) Disjoint(g *Geometry, o toGeos) (bool, error) {
+	return ops.predicate(g, g.g.Disjoint, o)
+}
+
+func (ops Ops) Touches(g *Geometry, o toGeos) (bool, error) {
+	return ops.predicate(g, g.g.Touches, o)
+}
+
+func (ops Ops) Overlaps(g *Geometry, o toGeos) (bool, error) {
+	return ops.predicate(g, g.g.Overlaps, o)
+}
+
+func (ops Ops) Within(g *Geometry, o toGeos) (bool, error) {
+	return ops.predicate(g, g.g.Within, o)
+}
+
+func (ops Ops) Crosses(g *Geometry, o toGeos) (bool, error) {
+	return ops.predicate(g, g.g.Crosses, o)
+}
+
+func (ops Ops) Covers(g *Geometry, o toGeos) (bool, error) {
+	return ops.predicate(g, g.g.Covers, o)
+}
+
+func (ops Ops) CoveredBy(g *Geometry, o toGeos) (bool, error) {
+	return ops.predicate(g, g.g.CoveredBy, o)
+}
+
+func (ops Ops) Equals(g *Geometry, o toGeos) (bool, error) {
+	return ops.predicate(g, g.g.Equals, o)
+}
+
+func (ops Ops) Intersection(g *Geometry, o toGeos) (*Geometry, error) {
+	return ops.operation(g, g.g.Intersection, o)
+}
+
+func (ops Ops) Union(g *Geometry, o toGeos) (*Geometry, error) {
+	return ops.operation(g, g.g.Union, o)
+}
+
+func (ops Ops) predicate(
+	g *Geometry, op binaryPredicate, o toGeos) (bool, error) {
+
+	defer runtime.KeepAlive(g)
+	defer runtime.KeepAlive(o)
+	return op(ops.h, o.UnsafeToGeos())
+}
+
+func (ops Ops) operation(
+	g *Geometry, op binaryOp, o toGeos) (*Geometry, error) {
+
+	defer runtime.KeepAlive(g)
+	defer runtime.KeepAlive(o)
+	geom, err := op(ops.h, o.UnsafeToGeos())
+	return newGeometryOrError(ops.hp, ops.h, geom, err)
+}
+
+func (ops Ops) coords(g *geos.Geometry) ([]Coord, error) {
+	return geosCoords(ops.h, g)
+}
+
+func geosCoords(h *geos.Handle, g *geos.Geometry) ([]Coord, error) {
+	xy, err := geosXY(h, g)
+	if err != nil || xy == nil {
+		return nil, err
+	}
+	coords := make([]Coord, len(xy)/2)
+	for i := range coords {
+		coords[i] = Coord{X: xy[2*i], Y: xy[2*i+1]}
+	}
+	return coords, nil
+}
+
+// Copies the geometry's coordinates out as interleaved x, y pairs
+func geosXY(h *geos.Handle, g *geos.Geometry) ([]float64, error) {
+	cs, err := g.CoordSeq(h)
+	if err != nil {
+		return nil, err
+	}
+	size := cs.Size(h)
+	if size == 0 {
+		return nil, nil
+	}
+	xy := make([]float64, 2*size)
+	if err := cs.CopyXY(h, xy); err != nil {
+		return nil, err
+	}
+	return xy, nil
+}
@@ -0,0 +1,94 @@
+package geom
+
+import (
+	"testing"
+
+	"github.com/vistarmedia/geom/geos-go/handle"
+)
+
+func TestWithHandleLeasesOnce(t *testing.T) {
+	ip := handle.NewInstrumentedHandleProvider(handle.NewPooledHandleProvider())
+	f := NewFactory(ip)
+	square, err := f.NewPolygon([]Coord{{0, 0}, {10, 0}, {10, 10}, {0, 10}, {0, 0}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	point, err := f.NewPoint(Coord{5, 5})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	before := ip.Stats().Gets
+	err = f.WithHandle(func(ops Ops) error {
+		if area := ops.Area(square.Geometry); area != 100 {
+			t.Errorf("Expected area of 100, got %f", area)
+		}
+		if typ := ops.Type(point.Geometry); typ != POINT {
+			t.Errorf("Expected POINT, got %d", typ)
+		}
+		c0, c1, err := ops.Bounds(square.Geometry)
+		if err != nil {
+			return err
+		}
+		if c0 != (Coord{0, 0}) || c1 != (Coord{10, 10}) {
+			t.Errorf("Unexpected bounds %v %v", c0, c1)
+		}
+		covers, err := ops.Covers(square.Geometry, point)
+		if err != nil {
+			return err
+		}
+		if !covers {
+			t.Error("Expected square to cover point")
+		}
+		coords, err := ops.Coords(point.Geometry)
+		if err != nil {
+			return err
+		}
+		if !compareCoordSlice(coords, []Coord{{5, 5}}) {
+			t.Errorf("Unexpected point coords %v", coords)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if gets := ip.Stats().Gets - before; gets != 1 {
+		t.Errorf("Expected 1 lease, got %d", gets)
+	}
+}
+
+func TestOpsShellAndHoles(t *testing.T) {
+	shell := []Coord{{0, 0}, {10, 0}, {10, 10}, {0, 10}, {0, 0}}
+	hole := []Coord{{2, 2}, {2, 4}, {4, 4}, {4, 2}, {2, 2}}
+	poly, err := fact.NewPolygon(shell, hole)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	actShell, err := poly.Shell()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !compareCoordSlice(actShell, shell) {
+		t.Errorf("Unexpected shell %v", actShell)
+	}
+
+	holes, err := poly.Holes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(holes) != 1 || !compareCoordSlice(holes[0], hole) {
+		t.Errorf("Unexpected holes %v", holes)
+	}
+}
+
+func TestOpsEmptyExtent(t *testing.T) {
+	empty := fact.NewEmptyPolygon()
+	err := fact.WithHandle(func(ops Ops) error {
+		_, err := ops.Extent(empty.Geometry)
+		return err
+	})
+	if err != ErrEmptyGeometry {
+		t.Errorf("Expected ErrEmptyGeometry, got %v", err)
+	}
+}
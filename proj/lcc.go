@@ -0,0 +1,96 @@
+package proj
+
+import (
+	"math"
+
+	"github.com/vistarmedia/geom"
+)
+
+// Ellipsoidal Lambert Conformal Conic with two standard parallels. Angles are
+// in degrees. False easting and northing are in meters.
+type LambertConformalConic struct {
+	// Central meridian and latitude of origin
+	Lon0, Lat0 float64
+	// Standard parallels, where the scale is exact
+	Lat1, Lat2    float64
+	FalseEasting  float64
+	FalseNorthing float64
+	// Meters per unit of projected coordinates. Defaults to Meter.
+	Units float64
+	// EPSG code, if any
+	EPSGCode int
+}
+
+func (lcc LambertConformalConic) SRID() int {
+	return lcc.EPSGCode
+}
+
+func (lcc LambertConformalConic) FromLonLat(c geom.Coord) (geom.Coord, error) {
+	n, F, rho0 := lcc.cone()
+	phi := radians(c.Y)
+	if math.Abs(phi) >= math.Pi/2 && phi*n <= 0 {
+		// The pole opposite the cone's apex projects to infinity
+		return geom.Coord{}, ErrOutOfBounds
+	}
+	rho := semiMajorAxis * F * math.Pow(lccT(phi), n)
+	theta := n * math.Remainder(radians(c.X-lcc.Lon0), 2*math.Pi)
+	units := unitsOrMeters(lcc.Units)
+	return geom.Coord{
+		X: (lcc.FalseEasting + rho*math.Sin(theta)) / units,
+		Y: (lcc.FalseNorthing + rho0 - rho*math.Cos(theta)) / units,
+	}, nil
+}
+
+func (lcc LambertConformalConic) ToLonLat(c geom.Coord) (geom.Coord, error) {
+	n, F, rho0 := lcc.cone()
+	units := unitsOrMeters(lcc.Units)
+	x := c.X*units - lcc.FalseEasting
+	y := rho0 - (c.Y*units - lcc.FalseNorthing)
+	if n < 0 {
+		x, y = -x, -y
+	}
+	rho := math.Copysign(math.Hypot(x, y), n)
+	theta := math.Atan2(x, y)
+	t := math.Pow(rho/(semiMajorAxis*F), 1/n)
+
+	phi := math.Pi/2 - 2*math.Atan(t)
+	for i := 0; i < 15; i++ {
+		esin := e * math.Sin(phi)
+		next := math.Pi/2 -
+			2*math.Atan(t*math.Pow((1-esin)/(1+esin), e/2))
+		if math.Abs(next-phi) < 1e-12 {
+			phi = next
+			break
+		}
+		phi = next
+	}
+	return geom.Coord{
+		X: degrees(math.Remainder(theta/n+radians(lcc.Lon0), 2*math.Pi)),
+		Y: degrees(phi),
+	}, nil
+}
+
+// Cone constant, scale and radius at the latitude of origin
+func (lcc LambertConformalConic) cone() (n, F, rho0 float64) {
+	phi1, phi2 := radians(lcc.Lat1), radians(lcc.Lat2)
+	m1, m2 := lccM(phi1), lccM(phi2)
+	t1, t2 := lccT(phi1), lccT(phi2)
+	if phi1 == phi2 {
+		n = math.Sin(phi1)
+	} else {
+		n = (math.Log(m1) - math.Log(m2)) / (math.Log(t1) - math.Log(t2))
+	}
+	F = m1 / (n * math.Pow(t1, n))
+	rho0 = semiMajorAxis * F * math.Pow(lccT(radians(lcc.Lat0)), n)
+	return
+}
+
+func lccM(phi float64) float64 {
+	sinPhi := math.Sin(phi)
+	return math.Cos(phi) / math.Sqrt(1-e2*sinPhi*sinPhi)
+}
+
+func lccT(phi float64) float64 {
+	esin := e * math.Sin(phi)
+	return math.Tan(math.Pi/4-phi/2) / math.Pow((1-esin)/(1+esin), e/2)
+}
@@ -0,0 +1,147 @@
+// Package proj converts geometries between coordinate reference systems
+// without linking PROJ. Projections are pure Go and use the WGS84 ellipsoid.
+// NAD83 based systems, such as State Plane, are treated as WGS84; the datums
+// differ by about a meter.
+package proj
+
+import (
+	"errors"
+	"math"
+
+	"github.com/vistarmedia/geom"
+)
+
+// WGS84 ellipsoid
+const (
+	semiMajorAxis = 6378137.0
+	flattening    = 1 / 298.257223563
+)
+
+// Meters per unit of projected coordinates
+const (
+	Meter        = 1.0
+	USSurveyFoot = 1200.0 / 3937
+)
+
+var (
+	ErrUnknownCRS  = errors.New("proj: Unknown CRS")
+	ErrOutOfBounds = errors.New("proj: Coordinate outside of projection bounds")
+)
+
+var (
+	e2 = flattening * (2 - flattening)
+	e  = math.Sqrt(e2)
+)
+
+// A coordinate reference system, converting to and from WGS84 longitude and
+// latitude in degrees.
+type CRS interface {
+	// EPSG code, or 0 if the CRS has none
+	SRID() int
+	FromLonLat(geom.Coord) (geom.Coord, error)
+	ToLonLat(geom.Coord) (geom.Coord, error)
+}
+
+// Longitude and latitude in degrees. EPSG:4326.
+type Geographic struct{}
+
+// EPSG:4326
+var WGS84 CRS = Geographic{}
+
+func (Geographic) SRID() int {
+	return 4326
+}
+
+func (Geographic) FromLonLat(c geom.Coord) (geom.Coord, error) {
+	return c, nil
+}
+
+func (Geographic) ToLonLat(c geom.Coord) (geom.Coord, error) {
+	return c, nil
+}
+
+// Spherical Mercator used by web maps, in meters. EPSG:3857.
+type WebMercator struct{}
+
+// Latitude at which Web Mercator's extent is square
+const MaxMercatorLatitude = 85.05112877980659
+
+func (WebMercator) SRID() int {
+	return 3857
+}
+
+func (WebMercator) FromLonLat(c geom.Coord) (geom.Coord, error) {
+	if math.Abs(c.Y) > MaxMercatorLatitude {
+		return geom.Coord{}, ErrOutOfBounds
+	}
+	return geom.Coord{
+		X: semiMajorAxis * radians(c.X),
+		Y: semiMajorAxis * math.Log(math.Tan(math.Pi/4+radians(c.Y)/2)),
+	}, nil
+}
+
+func (WebMercator) ToLonLat(c geom.Coord) (geom.Coord, error) {
+	return geom.Coord{
+		X: degrees(c.X / semiMajorAxis),
+		Y: degrees(2*math.Atan(math.Exp(c.Y/semiMajorAxis)) - math.Pi/2),
+	}, nil
+}
+
+// Looks up a CRS by EPSG code. Supports 4326, 3857, the WGS84 UTM zones
+// 32601-32660 and 32701-32760, and the State Plane zones in StatePlane.
+func EPSG(code int) (CRS, error) {
+	switch {
+	case code == 4326:
+		return WGS84, nil
+	case code == 3857:
+		return WebMercator{}, nil
+	case code > 32600 && code <= 32660:
+		return UTM(code-32600, true), nil
+	case code > 32700 && code <= 32760:
+		return UTM(code-32700, false), nil
+	}
+	if crs, ok := StatePlane[code]; ok {
+		return crs, nil
+	}
+	return nil, ErrUnknownCRS
+}
+
+// Converts g from one CRS to another. The result's SRID is set to to's SRID.
+func Transform(g *geom.Geometry, from, to CRS) (*geom.Geometry, error) {
+	var opts []geom.FactoryOption
+	if srid := to.SRID(); srid != 0 {
+		opts = append(opts, geom.WithSRID(srid))
+	}
+	return Apply(g, func(c geom.Coord) (geom.Coord, error) {
+		lonLat, err := from.ToLonLat(c)
+		if err != nil {
+			return geom.Coord{}, err
+		}
+		return to.FromLonLat(lonLat)
+	}, opts...)
+}
+
+// Rebuilds g with fn applied to every coordinate, preserving holes and
+// collection structure. The result is built by a Factory sharing g's handle
+// provider with the given options.
+func Apply(g *geom.Geometry, fn func(geom.Coord) (geom.Coord, error),
+	opts ...geom.FactoryOption) (*geom.Geometry, error) {
+
+	return g.Factory(opts...).MapErr(g, fn)
+}
+
+func radians(deg float64) float64 {
+	return deg * math.Pi / 180
+}
+
+func degrees(rad float64) float64 {
+	return rad * 180 / math.Pi
+}
+
+// Meters per unit, defaulting to meters
+func unitsOrMeters(units float64) float64 {
+	if units == 0 {
+		return Meter
+	}
+	return units
+}
@@ -0,0 +1,210 @@
+package proj
+
+import (
+	"math"
+	"testing"
+
+	"github.com/vistarmedia/geom"
+	"github.com/vistarmedia/geom/geos-go/handle"
+)
+
+var fact = geom.NewFactory(handle.NewPooledHandleProvider())
+
+func assertCoord(t *testing.T, exp, act geom.Coord, tol float64) {
+	t.Helper()
+	if math.Abs(exp.X-act.X) > tol || math.Abs(exp.Y-act.Y) > tol {
+		t.Errorf("Expected %v, got %v", exp, act)
+	}
+}
+
+func assertRoundTrip(t *testing.T, crs CRS, c geom.Coord) {
+	t.Helper()
+	xy, err := crs.FromLonLat(c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	back, err := crs.ToLonLat(xy)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertCoord(t, c, back, 1e-8)
+}
+
+func TestWebMercator(t *testing.T) {
+	xy, err := WebMercator{}.FromLonLat(geom.Coord{180, MaxMercatorLatitude})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertCoord(t, geom.Coord{20037508.3428, 20037508.3428}, xy, 1e-4)
+	assertRoundTrip(t, WebMercator{}, geom.Coord{-73.98, 40.75})
+
+	if _, err := (WebMercator{}).FromLonLat(geom.Coord{0, 89}); err != ErrOutOfBounds {
+		t.Errorf("Expected ErrOutOfBounds, got %v", err)
+	}
+}
+
+func TestUTM(t *testing.T) {
+	zone, north := UTMZone(geom.Coord{-75, 45})
+	if zone != 18 || !north {
+		t.Fatalf("Expected zone 18N, got %d %v", zone, north)
+	}
+	utm := UTM(zone, north)
+	if utm.SRID() != 32618 {
+		t.Errorf("Expected SRID 32618, got %d", utm.SRID())
+	}
+	// On the central meridian, northing is the scaled meridian arc
+	xy, err := utm.FromLonLat(geom.Coord{-75, 45})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertCoord(t, geom.Coord{500000, 4982950.400}, xy, 1e-3)
+
+	assertRoundTrip(t, utm, geom.Coord{-72, 10})
+	assertRoundTrip(t, UTM(18, false), geom.Coord{-78, -60})
+	if UTM(18, false).SRID() != 32718 {
+		t.Errorf("Expected SRID 32718, got %d", UTM(18, false).SRID())
+	}
+}
+
+func TestStatePlane(t *testing.T) {
+	for code, crs := range StatePlane {
+		if crs.SRID() != code {
+			t.Errorf("Expected SRID %d, got %d", code, crs.SRID())
+		}
+		var origin, offset geom.Coord
+		switch p := crs.(type) {
+		case LambertConformalConic:
+			origin = geom.Coord{p.Lon0, p.Lat0}
+			offset = geom.Coord{p.FalseEasting / unitsOrMeters(p.Units),
+				p.FalseNorthing / unitsOrMeters(p.Units)}
+		case TransverseMercator:
+			origin = geom.Coord{p.Lon0, p.Lat0}
+			offset = geom.Coord{p.FalseEasting / unitsOrMeters(p.Units),
+				p.FalseNorthing / unitsOrMeters(p.Units)}
+		}
+		xy, err := crs.FromLonLat(origin)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assertCoord(t, offset, xy, 1e-6)
+		assertRoundTrip(t, crs, geom.Coord{origin.X + 0.3, origin.Y + 1.2})
+	}
+
+	// Long Island in feet and meters
+	ft, _ := EPSG(2263)
+	m, _ := EPSG(32118)
+	c := geom.Coord{-73.98, 40.75}
+	xyFt, _ := ft.FromLonLat(c)
+	xyM, _ := m.FromLonLat(c)
+	assertCoord(t, geom.Coord{xyM.X / USSurveyFoot, xyM.Y / USSurveyFoot}, xyFt, 1e-6)
+}
+
+func TestEPSG(t *testing.T) {
+	for _, code := range []int{4326, 3857, 32601, 32660, 32701, 32760, 2263} {
+		crs, err := EPSG(code)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if crs.SRID() != code {
+			t.Errorf("Expected SRID %d, got %d", code, crs.SRID())
+		}
+	}
+	if _, err := EPSG(32661); err != ErrUnknownCRS {
+		t.Errorf("Expected ErrUnknownCRS, got %v", err)
+	}
+}
+
+func TestTransform(t *testing.T) {
+	shell := []geom.Coord{{-74, 40.7}, {-73.9, 40.7}, {-73.9, 40.8}, {-74, 40.8}, {-74, 40.7}}
+	hole := []geom.Coord{{-73.96, 40.74}, {-73.94, 40.74}, {-73.94, 40.76}, {-73.96, 40.74}}
+	poly, err := fact.NewPolygon(shell, hole)
+	if err != nil {
+		t.Fatal(err)
+	}
+	mp, err := fact.NewMultipolygon(poly)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mercator, err := Transform(mp.Geometry, WGS84, WebMercator{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mercator.Type() != geom.MULTIPOLYGON {
+		t.Fatalf("Expected MULTIPOLYGON, got %d", mercator.Type())
+	}
+	if mercator.SRID() != 3857 {
+		t.Errorf("Expected SRID 3857, got %d", mercator.SRID())
+	}
+	polys, err := mercator.Geometries()
+	if err != nil {
+		t.Fatal(err)
+	}
+	holes, err := polys[0].Polygon().Holes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(holes) != 1 || len(holes[0]) != len(hole) {
+		t.Fatalf("Expected hole to be preserved, got %v", holes)
+	}
+	exp, _ := WebMercator{}.FromLonLat(hole[0])
+	assertCoord(t, exp, holes[0][0], 1e-9)
+
+	back, err := Transform(mercator, WebMercator{}, WGS84)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if eq, err := back.EqualsExact(mp, 1e-9); err != nil {
+		t.Fatal(err)
+	} else if !eq {
+		t.Error("Expected round trip to return the original geometry")
+	}
+}
+
+func TestTransformError(t *testing.T) {
+	p, err := fact.NewPoint(geom.Coord{0, 89})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Transform(p.Geometry, WGS84, WebMercator{}); err != ErrOutOfBounds {
+		t.Errorf("Expected ErrOutOfBounds, got %v", err)
+	}
+}
+
+func TestTransformMulti(t *testing.T) {
+	a, err := fact.NewPoint(geom.Coord{-74, 40.7})
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := fact.NewPoint(geom.Coord{-73.9, 40.8})
+	if err != nil {
+		t.Fatal(err)
+	}
+	mpt, err := fact.NewMultiPoint(a, b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ls, err := fact.NewLineString([]geom.Coord{{-74, 40.7}, {-73.9, 40.8}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	mls, err := fact.NewMultiLineString(ls)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, g := range []*geom.Geometry{mpt, mls} {
+		out, err := Transform(g, WGS84, WebMercator{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if out.Type() != g.Type() {
+			t.Errorf("Expected type %d, got %d", g.Type(), out.Type())
+		}
+		if n, err := out.NumGeometries(); err != nil {
+			t.Fatal(err)
+		} else if exp, _ := g.NumGeometries(); n != exp {
+			t.Errorf("Expected %d members, got %d", exp, n)
+		}
+	}
+}
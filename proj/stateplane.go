@@ -0,0 +1,50 @@
+package proj
+
+// Common NAD83 State Plane zones by EPSG code
+var StatePlane = map[int]CRS{
+	// New York Long Island (ftUS)
+	2263: LambertConformalConic{
+		Lon0: -74, Lat0: 40.16666666666666,
+		Lat1: 41.03333333333333, Lat2: 40.66666666666666,
+		FalseEasting: 300000, Units: USSurveyFoot, EPSGCode: 2263,
+	},
+	// New York Long Island
+	32118: LambertConformalConic{
+		Lon0: -74, Lat0: 40.16666666666666,
+		Lat1: 41.03333333333333, Lat2: 40.66666666666666,
+		FalseEasting: 300000, EPSGCode: 32118,
+	},
+	// California zone 3 (ftUS)
+	2227: LambertConformalConic{
+		Lon0: -120.5, Lat0: 36.5,
+		Lat1: 38.43333333333333, Lat2: 37.06666666666667,
+		FalseEasting: 2000000, FalseNorthing: 500000,
+		Units: USSurveyFoot, EPSGCode: 2227,
+	},
+	// California zone 5 (ftUS)
+	2229: LambertConformalConic{
+		Lon0: -118, Lat0: 33.5,
+		Lat1: 35.46666666666667, Lat2: 34.03333333333333,
+		FalseEasting: 2000000, FalseNorthing: 500000,
+		Units: USSurveyFoot, EPSGCode: 2229,
+	},
+	// Massachusetts Mainland (ftUS)
+	2249: LambertConformalConic{
+		Lon0: -71.5, Lat0: 41,
+		Lat1: 42.68333333333333, Lat2: 41.71666666666667,
+		FalseEasting: 200000, FalseNorthing: 750000,
+		Units: USSurveyFoot, EPSGCode: 2249,
+	},
+	// Texas South Central (ftUS)
+	2278: LambertConformalConic{
+		Lon0: -99, Lat0: 27.83333333333333,
+		Lat1: 30.28333333333333, Lat2: 28.38333333333333,
+		FalseEasting: 600000, FalseNorthing: 4000000,
+		Units: USSurveyFoot, EPSGCode: 2278,
+	},
+	// Illinois East (ftUS)
+	3435: TransverseMercator{
+		Lon0: -88.33333333333333, Lat0: 36.66666666666666, K0: 0.999975,
+		FalseEasting: 300000, Units: USSurveyFoot, EPSGCode: 3435,
+	},
+}
@@ -0,0 +1,128 @@
+package proj
+
+import (
+	"math"
+
+	"github.com/vistarmedia/geom"
+)
+
+// Ellipsoidal Transverse Mercator using Krüger's series to third order in n,
+// accurate to about a millimeter within 3000km of the central meridian.
+// Angles are in degrees. False easting and northing are in meters.
+type TransverseMercator struct {
+	// Central meridian and latitude of origin
+	Lon0, Lat0 float64
+	// Scale factor on the central meridian
+	K0            float64
+	FalseEasting  float64
+	FalseNorthing float64
+	// Meters per unit of projected coordinates. Defaults to Meter.
+	Units float64
+	// EPSG code, if any
+	EPSGCode int
+}
+
+// Krüger series coefficients
+var (
+	tmN = flattening / (2 - flattening)
+	// Radius of the rectifying sphere
+	tmA     = semiMajorAxis / (1 + tmN) * (1 + tmN*tmN/4 + tmN*tmN*tmN*tmN/64)
+	tmAlpha = [3]float64{
+		tmN/2 - 2*tmN*tmN/3 + 5*tmN*tmN*tmN/16,
+		13*tmN*tmN/48 - 3*tmN*tmN*tmN/5,
+		61 * tmN * tmN * tmN / 240,
+	}
+	tmBeta = [3]float64{
+		tmN/2 - 2*tmN*tmN/3 + 37*tmN*tmN*tmN/96,
+		tmN*tmN/48 + tmN*tmN*tmN/15,
+		17 * tmN * tmN * tmN / 480,
+	}
+	tmDelta = [3]float64{
+		2*tmN - 2*tmN*tmN/3 - 2*tmN*tmN*tmN,
+		7*tmN*tmN/3 - 8*tmN*tmN*tmN/5,
+		56 * tmN * tmN * tmN / 15,
+	}
+)
+
+// WGS84 Universal Transverse Mercator zone 1-60, north or south of the equator
+func UTM(zone int, north bool) TransverseMercator {
+	tm := TransverseMercator{
+		Lon0:         float64(zone)*6 - 183,
+		K0:           0.9996,
+		FalseEasting: 500000,
+		EPSGCode:     32600 + zone,
+	}
+	if !north {
+		tm.FalseNorthing = 10000000
+		tm.EPSGCode = 32700 + zone
+	}
+	return tm
+}
+
+// UTM zone containing a longitude/latitude. Ignores the Norway and Svalbard
+// exceptions.
+func UTMZone(c geom.Coord) (zone int, north bool) {
+	zone = int(math.Floor((c.X+180)/6)) + 1
+	if zone > 60 {
+		zone = 1
+	}
+	return zone, c.Y >= 0
+}
+
+func (tm TransverseMercator) SRID() int {
+	return tm.EPSGCode
+}
+
+func (tm TransverseMercator) FromLonLat(c geom.Coord) (geom.Coord, error) {
+	dLon := math.Remainder(radians(c.X-tm.Lon0), 2*math.Pi)
+	if math.Abs(dLon) >= math.Pi/2 {
+		return geom.Coord{}, ErrOutOfBounds
+	}
+	xi, eta := tmForward(radians(c.Y), dLon)
+	xi0, _ := tmForward(radians(tm.Lat0), 0)
+	units := unitsOrMeters(tm.Units)
+	return geom.Coord{
+		X: (tm.FalseEasting + tm.K0*tmA*eta) / units,
+		Y: (tm.FalseNorthing + tm.K0*tmA*(xi-xi0)) / units,
+	}, nil
+}
+
+func (tm TransverseMercator) ToLonLat(c geom.Coord) (geom.Coord, error) {
+	units := unitsOrMeters(tm.Units)
+	xi0, _ := tmForward(radians(tm.Lat0), 0)
+	xi := (c.Y*units-tm.FalseNorthing)/(tm.K0*tmA) + xi0
+	eta := (c.X*units - tm.FalseEasting) / (tm.K0 * tmA)
+
+	xiP, etaP := xi, eta
+	for j, beta := range tmBeta {
+		k := 2 * float64(j+1)
+		xiP -= beta * math.Sin(k*xi) * math.Cosh(k*eta)
+		etaP -= beta * math.Cos(k*xi) * math.Sinh(k*eta)
+	}
+	chi := math.Asin(math.Sin(xiP) / math.Cosh(etaP))
+	phi := chi
+	for j, delta := range tmDelta {
+		phi += delta * math.Sin(2*float64(j+1)*chi)
+	}
+	lambda := math.Atan2(math.Sinh(etaP), math.Cos(xiP))
+	return geom.Coord{
+		X: degrees(math.Remainder(radians(tm.Lon0)+lambda, 2*math.Pi)),
+		Y: degrees(phi),
+	}, nil
+}
+
+// Northing and easting on the rectifying sphere, in radians, of a latitude and
+// a longitude relative to the central meridian
+func tmForward(phi, dLon float64) (xi, eta float64) {
+	sinPhi := math.Sin(phi)
+	t := math.Sinh(math.Atanh(sinPhi) - e*math.Atanh(e*sinPhi))
+	xiP := math.Atan2(t, math.Cos(dLon))
+	etaP := math.Atanh(math.Sin(dLon) / math.Sqrt(1+t*t))
+	xi, eta = xiP, etaP
+	for j, alpha := range tmAlpha {
+		k := 2 * float64(j+1)
+		xi += alpha * math.Sin(k*xiP) * math.Cosh(k*etaP)
+		eta += alpha * math.Cos(k*xiP) * math.Sinh(k*etaP)
+	}
+	return
+}
@@ -0,0 +1,78 @@
+package rtree
+
+import (
+	"github.com/vistarmedia/geom"
+)
+
+// Bounding box of a geometry
+func Bounds(g *geom.Geometry) (Rect, error) {
+	c0, c1, err := g.Bounds()
+	return Rect{Min: c0, Max: c1}, err
+}
+
+// Indexes g by its bounding box.
+func (t *RTree) Insert(g *geom.Geometry, value interface{}) (*Item, error) {
+	r, err := Bounds(g)
+	if err != nil {
+		return nil, err
+	}
+	item := &Item{Rect: r, Geometry: g, Value: value}
+	t.Lock()
+	t.insert(item)
+	t.Unlock()
+	return item, nil
+}
+
+// Prepared form of the item's geometry, created on first use. Nil for items
+// inserted with InsertRect.
+func (it *Item) Prepared() *geom.PreparedGeometry {
+	if it.Geometry == nil {
+		return nil
+	}
+	it.prepOnce.Do(func() {
+		it.prepared = it.Geometry.Prepared()
+	})
+	return it.prepared
+}
+
+// Items whose geometries intersect g. Items inserted with InsertRect are
+// matched by their boxes alone.
+func (t *RTree) SearchIntersects(g *geom.Geometry) ([]*Item, error) {
+	return t.refine(g, func(p *geom.PreparedGeometry) (bool, error) {
+		return p.Intersects(g)
+	})
+}
+
+// Items whose geometries cover g, including geometries lying on their
+// boundaries. Items inserted with InsertRect are matched by their boxes alone.
+func (t *RTree) SearchCovers(g *geom.Geometry) ([]*Item, error) {
+	return t.refine(g, func(p *geom.PreparedGeometry) (bool, error) {
+		return p.Covers(g)
+	})
+}
+
+// Filters items whose boxes intersect g by an exact predicate
+func (t *RTree) refine(g *geom.Geometry,
+	pred func(*geom.PreparedGeometry) (bool, error)) ([]*Item, error) {
+
+	r, err := Bounds(g)
+	if err != nil {
+		return nil, err
+	}
+	var items []*Item
+	for _, item := range t.Search(r) {
+		prep := item.Prepared()
+		if prep == nil {
+			items = append(items, item)
+			continue
+		}
+		ok, err := pred(prep)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			items = append(items, item)
+		}
+	}
+	return items, nil
+}
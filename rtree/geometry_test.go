@@ -0,0 +1,110 @@
+package rtree
+
+import (
+	"testing"
+
+	"github.com/vistarmedia/geom"
+	"github.com/vistarmedia/geom/geos-go/handle"
+)
+
+var fact = geom.NewFactory(handle.NewPooledHandleProvider())
+
+func square(t *testing.T, x, y, size float64) *geom.Geometry {
+	poly, err := fact.NewPolygon([]geom.Coord{
+		{X: x, Y: y},
+		{X: x + size, Y: y},
+		{X: x + size, Y: y + size},
+		{X: x, Y: y + size},
+		{X: x, Y: y},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return poly.Geometry
+}
+
+func point(t *testing.T, x, y float64) *geom.Geometry {
+	p, err := fact.NewPoint(geom.Coord{X: x, Y: y})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return p.Geometry
+}
+
+func TestBoundsOfPoint(t *testing.T) {
+	r, err := Bounds(point(t, 3, 4))
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp := geom.Coord{X: 3, Y: 4}
+	if r.Min != exp || r.Max != exp {
+		t.Errorf("Expected %v, got %v", exp, r)
+	}
+}
+
+func TestSearchCovers(t *testing.T) {
+	tree := New(4)
+	// A triangle whose box covers (8, 8), but which does not
+	triangle, err := fact.NewPolygon([]geom.Coord{
+		{X: 0, Y: 0},
+		{X: 10, Y: 0},
+		{X: 0, Y: 10},
+		{X: 0, Y: 0},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tree.Insert(triangle.Geometry, "triangle"); err != nil {
+		t.Fatal(err)
+	}
+	zone, err := tree.Insert(square(t, 5, 5, 5), "zone")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	items, err := tree.SearchCovers(point(t, 8, 8))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(items) != 1 || items[0].Value != "zone" {
+		t.Errorf("Expected only zone, got %v", items)
+	}
+
+	// Boundary points are covered
+	items, err = tree.SearchCovers(point(t, 10, 10))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(items) != 1 || items[0] != zone {
+		t.Errorf("Expected zone to cover its corner, got %v", items)
+	}
+
+	tree.Delete(zone)
+	items, err = tree.SearchCovers(point(t, 8, 8))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(items) != 0 {
+		t.Errorf("Expected no items after delete, got %v", items)
+	}
+}
+
+func TestSearchIntersects(t *testing.T) {
+	tree := New(4)
+	for i := 0; i < 10; i++ {
+		if _, err := tree.Insert(square(t, float64(i)*2, 0, 1), i); err != nil {
+			t.Fatal(err)
+		}
+	}
+	items, err := tree.SearchIntersects(square(t, 3.5, 0.5, 3))
+	if err != nil {
+		t.Fatal(err)
+	}
+	found := make(map[interface{}]bool)
+	for _, item := range items {
+		found[item.Value] = true
+	}
+	if len(found) != 2 || !found[2] || !found[3] {
+		t.Errorf("Expected squares 2 and 3, got %v", found)
+	}
+}
@@ -0,0 +1,472 @@
+// Package rtree implements a dynamic, pure Go R-tree over geometry bounds.
+//
+// Unlike geom.STRtree, items can be inserted and deleted at any time without
+// rebuilding the tree. The tree only indexes bounding boxes; see SearchIntersects
+// and SearchCovers for exact refinement against the indexed geometries.
+package rtree
+
+import (
+	"container/heap"
+	"math"
+	"sync"
+
+	"github.com/vistarmedia/geom"
+)
+
+const (
+	DefaultMaxEntries = 16
+)
+
+// Axis aligned bounding box
+type Rect struct {
+	Min, Max geom.Coord
+}
+
+func (r Rect) Intersects(o Rect) bool {
+	return r.Min.X <= o.Max.X && o.Min.X <= r.Max.X &&
+		r.Min.Y <= o.Max.Y && o.Min.Y <= r.Max.Y
+}
+
+func (r Rect) Contains(o Rect) bool {
+	return r.Min.X <= o.Min.X && o.Max.X <= r.Max.X &&
+		r.Min.Y <= o.Min.Y && o.Max.Y <= r.Max.Y
+}
+
+func (r Rect) Union(o Rect) Rect {
+	return Rect{
+		Min: geom.Coord{X: math.Min(r.Min.X, o.Min.X), Y: math.Min(r.Min.Y, o.Min.Y)},
+		Max: geom.Coord{X: math.Max(r.Max.X, o.Max.X), Y: math.Max(r.Max.Y, o.Max.Y)},
+	}
+}
+
+func (r Rect) Area() float64 {
+	return (r.Max.X - r.Min.X) * (r.Max.Y - r.Min.Y)
+}
+
+// Minimum distance from c to any point in the rect
+func (r Rect) Distance(c geom.Coord) float64 {
+	dx := math.Max(0, math.Max(r.Min.X-c.X, c.X-r.Max.X))
+	dy := math.Max(0, math.Max(r.Min.Y-c.Y, c.Y-r.Max.Y))
+	return math.Hypot(dx, dy)
+}
+
+func (r Rect) enlargement(o Rect) float64 {
+	return r.Union(o).Area() - r.Area()
+}
+
+// An entry in the tree. Items are returned from Insert and are used as the
+// key to Delete.
+type Item struct {
+	Rect Rect
+	// Nil for items inserted with InsertRect
+	Geometry *geom.Geometry
+	Value    interface{}
+
+	leaf     *node
+	prepOnce sync.Once
+	prepared *geom.PreparedGeometry
+}
+
+type node struct {
+	rect     Rect
+	parent   *node
+	leaf     bool
+	children []*node
+	items    []*Item
+}
+
+func (n *node) size() int {
+	if n.leaf {
+		return len(n.items)
+	}
+	return len(n.children)
+}
+
+func (n *node) recalculate() {
+	if n.leaf {
+		for i, item := range n.items {
+			if i == 0 {
+				n.rect = item.Rect
+			} else {
+				n.rect = n.rect.Union(item.Rect)
+			}
+		}
+	} else {
+		for i, child := range n.children {
+			if i == 0 {
+				n.rect = child.rect
+			} else {
+				n.rect = n.rect.Union(child.rect)
+			}
+		}
+	}
+}
+
+func (n *node) addChild(child *node) {
+	child.parent = n
+	n.children = append(n.children, child)
+}
+
+func (n *node) addItem(item *Item) {
+	item.leaf = n
+	n.items = append(n.items, item)
+}
+
+// Appends all items in this subtree to items
+func (n *node) collect(items []*Item) []*Item {
+	if n.leaf {
+		return append(items, n.items...)
+	}
+	for _, child := range n.children {
+		items = child.collect(items)
+	}
+	return items
+}
+
+// Guttman's R-tree with quadratic splits. Safe for use across goroutines.
+type RTree struct {
+	root       *node
+	maxEntries int
+	minEntries int
+	size       int
+	sync.RWMutex
+}
+
+// Creates an empty tree whose nodes hold at most maxEntries entries. Values
+// less than 4 use DefaultMaxEntries.
+func New(maxEntries int) *RTree {
+	if maxEntries < 4 {
+		maxEntries = DefaultMaxEntries
+	}
+	return &RTree{
+		root:       &node{leaf: true},
+		maxEntries: maxEntries,
+		minEntries: int(math.Max(2, math.Ceil(float64(maxEntries)*0.4))),
+	}
+}
+
+// Number of items in the tree
+func (t *RTree) Len() int {
+	t.RLock()
+	defer t.RUnlock()
+	return t.size
+}
+
+// Indexes a box which is not associated with a geometry.
+func (t *RTree) InsertRect(r Rect, value interface{}) *Item {
+	item := &Item{Rect: r, Value: value}
+	t.Lock()
+	t.insert(item)
+	t.Unlock()
+	return item
+}
+
+// Removes an item returned from Insert or InsertRect. Returns false if the item
+// is not in the tree.
+func (t *RTree) Delete(item *Item) bool {
+	t.Lock()
+	defer t.Unlock()
+
+	leaf := item.leaf
+	if leaf == nil || !t.owns(leaf) {
+		return false
+	}
+	for i, it := range leaf.items {
+		if it == item {
+			leaf.items = append(leaf.items[:i], leaf.items[i+1:]...)
+			item.leaf = nil
+			t.size--
+			t.condense(leaf)
+			return true
+		}
+	}
+	return false
+}
+
+// All items whose boxes intersect r
+func (t *RTree) Search(r Rect) []*Item {
+	t.RLock()
+	defer t.RUnlock()
+	if t.size == 0 {
+		return nil
+	}
+	return search(t.root, r, nil)
+}
+
+// The k items whose boxes are closest to c, closest first. Items whose boxes
+// contain c have a distance of 0.
+func (t *RTree) Nearest(c geom.Coord, k int) []*Item {
+	t.RLock()
+	defer t.RUnlock()
+
+	var items []*Item
+	queue := &nearestQueue{{node: t.root, dist: t.root.rect.Distance(c)}}
+	for queue.Len() > 0 && len(items) < k {
+		e := heap.Pop(queue).(nearestEntry)
+		switch {
+		case e.item != nil:
+			items = append(items, e.item)
+		case e.node.leaf:
+			for _, item := range e.node.items {
+				heap.Push(queue, nearestEntry{item: item, dist: item.Rect.Distance(c)})
+			}
+		default:
+			for _, child := range e.node.children {
+				heap.Push(queue, nearestEntry{node: child, dist: child.rect.Distance(c)})
+			}
+		}
+	}
+	return items
+}
+
+// Guards against deleting an item which belongs to another tree
+func (t *RTree) owns(n *node) bool {
+	for n.parent != nil {
+		n = n.parent
+	}
+	return n == t.root
+}
+
+func search(n *node, r Rect, items []*Item) []*Item {
+	if !n.rect.Intersects(r) {
+		return items
+	}
+	if n.leaf {
+		for _, item := range n.items {
+			if item.Rect.Intersects(r) {
+				items = append(items, item)
+			}
+		}
+		return items
+	}
+	for _, child := range n.children {
+		items = search(child, r, items)
+	}
+	return items
+}
+
+func (t *RTree) insert(item *Item) {
+	leaf := t.chooseLeaf(item.Rect)
+	leaf.addItem(item)
+	t.size++
+	t.adjust(leaf)
+}
+
+// Descends to the leaf needing the least enlargement to include r
+func (t *RTree) chooseLeaf(r Rect) *node {
+	n := t.root
+	for !n.leaf {
+		var best *node
+		bestEnlargement, bestArea := math.Inf(1), math.Inf(1)
+		for _, child := range n.children {
+			enlargement := child.rect.enlargement(r)
+			area := child.rect.Area()
+			if enlargement < bestEnlargement ||
+				(enlargement == bestEnlargement && area < bestArea) {
+				best, bestEnlargement, bestArea = child, enlargement, area
+			}
+		}
+		n = best
+	}
+	return n
+}
+
+// Walks from n to the root, splitting overfull nodes and updating boxes
+func (t *RTree) adjust(n *node) {
+	for n != nil {
+		var sibling *node
+		if n.size() > t.maxEntries {
+			sibling = t.split(n)
+		}
+		n.recalculate()
+
+		if sibling != nil {
+			sibling.recalculate()
+			if n.parent == nil {
+				root := &node{}
+				root.addChild(n)
+				root.addChild(sibling)
+				root.recalculate()
+				t.root = root
+				return
+			}
+			n.parent.addChild(sibling)
+		}
+		n = n.parent
+	}
+}
+
+// Walks from a leaf which has lost an item to the root, removing underfull
+// nodes and reinserting their items.
+func (t *RTree) condense(n *node) {
+	var orphans []*Item
+	for n.parent != nil {
+		parent := n.parent
+		if n.size() < t.minEntries {
+			for i, child := range parent.children {
+				if child == n {
+					parent.children = append(parent.children[:i], parent.children[i+1:]...)
+					break
+				}
+			}
+			n.parent = nil
+			orphans = n.collect(orphans)
+		} else {
+			n.recalculate()
+		}
+		n = parent
+	}
+	t.root.recalculate()
+
+	for !t.root.leaf && len(t.root.children) == 1 {
+		t.root = t.root.children[0]
+		t.root.parent = nil
+	}
+	if !t.root.leaf && len(t.root.children) == 0 {
+		t.root = &node{leaf: true}
+	}
+
+	for _, item := range orphans {
+		t.size--
+		t.insert(item)
+	}
+}
+
+// Moves roughly half of n's entries to a new sibling node, which is returned.
+func (t *RTree) split(n *node) *node {
+	var rects []Rect
+	if n.leaf {
+		for _, item := range n.items {
+			rects = append(rects, item.Rect)
+		}
+	} else {
+		for _, child := range n.children {
+			rects = append(rects, child.rect)
+		}
+	}
+	groupA, groupB := quadraticSplit(rects, t.minEntries)
+
+	sibling := &node{leaf: n.leaf}
+	if n.leaf {
+		items := n.items
+		n.items = nil
+		for _, i := range groupA {
+			n.addItem(items[i])
+		}
+		for _, i := range groupB {
+			sibling.addItem(items[i])
+		}
+	} else {
+		children := n.children
+		n.children = nil
+		for _, i := range groupA {
+			n.addChild(children[i])
+		}
+		for _, i := range groupB {
+			sibling.addChild(children[i])
+		}
+	}
+	return sibling
+}
+
+// Partitions rects in to two groups of at least min entries, attempting to
+// minimize the area of each group's bounding box.
+func quadraticSplit(rects []Rect, min int) (a, b []int) {
+	// Seeds are the pair which would waste the most area if grouped together
+	seedA, seedB := 0, 1
+	worst := math.Inf(-1)
+	for i := 0; i < len(rects); i++ {
+		for j := i + 1; j < len(rects); j++ {
+			waste := rects[i].Union(rects[j]).Area() - rects[i].Area() - rects[j].Area()
+			if waste > worst {
+				seedA, seedB, worst = i, j, waste
+			}
+		}
+	}
+
+	a, b = []int{seedA}, []int{seedB}
+	rectA, rectB := rects[seedA], rects[seedB]
+	assigned := make([]bool, len(rects))
+	assigned[seedA], assigned[seedB] = true, true
+	remaining := len(rects) - 2
+
+	for remaining > 0 {
+		// If one group needs every remaining entry to reach the minimum, give it
+		// all of them.
+		if len(a)+remaining == min || len(b)+remaining == min {
+			toA := len(a)+remaining == min
+			for i := range rects {
+				if assigned[i] {
+					continue
+				}
+				if toA {
+					a = append(a, i)
+				} else {
+					b = append(b, i)
+				}
+			}
+			return
+		}
+
+		// Next entry is the one with the strongest preference for one group
+		next, maxDiff := -1, math.Inf(-1)
+		var nextEnlargeA, nextEnlargeB float64
+		for i, r := range rects {
+			if assigned[i] {
+				continue
+			}
+			enlargeA, enlargeB := rectA.enlargement(r), rectB.enlargement(r)
+			if diff := math.Abs(enlargeA - enlargeB); diff > maxDiff {
+				next, maxDiff = i, diff
+				nextEnlargeA, nextEnlargeB = enlargeA, enlargeB
+			}
+		}
+
+		assigned[next] = true
+		remaining--
+		toA := nextEnlargeA < nextEnlargeB
+		if nextEnlargeA == nextEnlargeB {
+			areaA, areaB := rectA.Area(), rectB.Area()
+			toA = areaA < areaB || (areaA == areaB && len(a) <= len(b))
+		}
+		if toA {
+			a = append(a, next)
+			rectA = rectA.Union(rects[next])
+		} else {
+			b = append(b, next)
+			rectB = rectB.Union(rects[next])
+		}
+	}
+	return
+}
+
+type nearestEntry struct {
+	node *node
+	item *Item
+	dist float64
+}
+
+type nearestQueue []nearestEntry
+
+func (q nearestQueue) Len() int { return len(q) }
+
+func (q nearestQueue) Less(i, j int) bool {
+	// Prefer items over nodes at equal distances so results are emitted as soon
+	// as they are known to be closest.
+	if q[i].dist == q[j].dist {
+		return q[i].item != nil && q[j].item == nil
+	}
+	return q[i].dist < q[j].dist
+}
+
+func (q nearestQueue) Swap(i, j int) { q[i], q[j] = q[j], q[i] }
+
+func (q *nearestQueue) Push(x interface{}) { *q = append(*q, x.(nearestEntry)) }
+
+func (q *nearestQueue) Pop() interface{} {
+	old := *q
+	n := len(old)
+	e := old[n-1]
+	*q = old[:n-1]
+	return e
+}
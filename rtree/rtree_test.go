@@ -0,0 +1,164 @@
+package rtree
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/vistarmedia/geom"
+)
+
+func randomRect(rnd *rand.Rand) Rect {
+	x, y := rnd.Float64()*100, rnd.Float64()*100
+	w, h := rnd.Float64()*5, rnd.Float64()*5
+	return Rect{
+		Min: geom.Coord{X: x, Y: y},
+		Max: geom.Coord{X: x + w, Y: y + h},
+	}
+}
+
+func bruteSearch(items []*Item, r Rect) map[*Item]bool {
+	found := make(map[*Item]bool)
+	for _, item := range items {
+		if item.Rect.Intersects(r) {
+			found[item] = true
+		}
+	}
+	return found
+}
+
+func compareSearch(t *testing.T, tree *RTree, items []*Item, r Rect) {
+	t.Helper()
+	exp := bruteSearch(items, r)
+	act := tree.Search(r)
+	if len(act) != len(exp) {
+		t.Fatalf("Expected %d items, got %d", len(exp), len(act))
+	}
+	for _, item := range act {
+		if !exp[item] {
+			t.Fatalf("Unexpected item %v", item.Value)
+		}
+	}
+}
+
+// Every node's box must contain its entries and no node may be overfull
+func checkInvariants(t *testing.T, tree *RTree, n *node, depth int, leafDepth *int) {
+	t.Helper()
+	if n.size() > tree.maxEntries {
+		t.Fatalf("Node has %d entries, max %d", n.size(), tree.maxEntries)
+	}
+	if n != tree.root && n.size() < tree.minEntries {
+		t.Fatalf("Node has %d entries, min %d", n.size(), tree.minEntries)
+	}
+	if n.leaf {
+		if *leafDepth == -1 {
+			*leafDepth = depth
+		} else if *leafDepth != depth {
+			t.Fatalf("Leaves at depth %d and %d", *leafDepth, depth)
+		}
+		for _, item := range n.items {
+			if item.leaf != n || !n.rect.Contains(item.Rect) {
+				t.Fatalf("Item %v not contained by its leaf", item.Value)
+			}
+		}
+		return
+	}
+	for _, child := range n.children {
+		if child.parent != n || !n.rect.Contains(child.rect) {
+			t.Fatal("Child not contained by its parent")
+		}
+		checkInvariants(t, tree, child, depth+1, leafDepth)
+	}
+}
+
+func TestInsertSearch(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	tree := New(8)
+	var items []*Item
+	for i := 0; i < 1000; i++ {
+		items = append(items, tree.InsertRect(randomRect(rnd), i))
+	}
+	if tree.Len() != 1000 {
+		t.Fatalf("Expected 1000 items, got %d", tree.Len())
+	}
+	leafDepth := -1
+	checkInvariants(t, tree, tree.root, 0, &leafDepth)
+
+	for i := 0; i < 100; i++ {
+		compareSearch(t, tree, items, randomRect(rnd))
+	}
+}
+
+func TestDelete(t *testing.T) {
+	rnd := rand.New(rand.NewSource(2))
+	tree := New(6)
+	var items []*Item
+	for i := 0; i < 500; i++ {
+		items = append(items, tree.InsertRect(randomRect(rnd), i))
+	}
+
+	rnd.Shuffle(len(items), func(i, j int) { items[i], items[j] = items[j], items[i] })
+	for len(items) > 0 {
+		item := items[len(items)-1]
+		items = items[:len(items)-1]
+		if !tree.Delete(item) {
+			t.Fatalf("Failed to delete item %v", item.Value)
+		}
+		if tree.Delete(item) {
+			t.Fatalf("Deleted item %v twice", item.Value)
+		}
+		if len(items)%50 == 0 {
+			leafDepth := -1
+			checkInvariants(t, tree, tree.root, 0, &leafDepth)
+			compareSearch(t, tree, items, randomRect(rnd))
+		}
+	}
+	if tree.Len() != 0 {
+		t.Errorf("Expected empty tree, got %d items", tree.Len())
+	}
+	if found := tree.Search(Rect{Max: geom.Coord{X: 100, Y: 100}}); len(found) != 0 {
+		t.Errorf("Expected no items, got %d", len(found))
+	}
+}
+
+func TestDeleteOtherTree(t *testing.T) {
+	tree1, tree2 := New(4), New(4)
+	item := tree1.InsertRect(Rect{}, 1)
+	if tree2.Delete(item) {
+		t.Error("Deleted an item from the wrong tree")
+	}
+	if tree1.Len() != 1 {
+		t.Error("Item should remain in its tree")
+	}
+}
+
+func TestNearest(t *testing.T) {
+	rnd := rand.New(rand.NewSource(3))
+	tree := New(8)
+	var items []*Item
+	for i := 0; i < 500; i++ {
+		items = append(items, tree.InsertRect(randomRect(rnd), i))
+	}
+
+	c := geom.Coord{X: 50, Y: 50}
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].Rect.Distance(c) < items[j].Rect.Distance(c)
+	})
+	nearest := tree.Nearest(c, 10)
+	if len(nearest) != 10 {
+		t.Fatalf("Expected 10 items, got %d", len(nearest))
+	}
+	for i, item := range nearest {
+		if item.Rect.Distance(c) != items[i].Rect.Distance(c) {
+			t.Errorf("Item %d at distance %f, expected %f",
+				i, item.Rect.Distance(c), items[i].Rect.Distance(c))
+		}
+	}
+
+	if all := tree.Nearest(c, 1000); len(all) != 500 {
+		t.Errorf("Expected all 500 items, got %d", len(all))
+	}
+	if none := New(8).Nearest(c, 3); len(none) != 0 {
+		t.Errorf("Expected no items from empty tree, got %d", len(none))
+	}
+}
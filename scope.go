@@ -0,0 +1,89 @@
+package geom
+
+import (
+	"sync"
+
+	"github.com/vistarmedia/geom/geos-go/handle"
+)
+
+// Tracks every geometry created through it so they can all be freed at once
+// with Close, rather than waiting on the GC. This includes geometries created
+// by the scope's Factory, by decoders built with that Factory, and by any
+// operation on a tracked geometry. For example:
+//
+//	scope := fact.NewScope()
+//	defer scope.Close()
+//	a, _ := scope.Factory().NewPolygon(shell)
+//	buffered, _ := a.Buffer(10, 8)        // tracked
+//	result, _ := buffered.Intersection(b) // tracked
+//	return scope.Keep(result)             // survives Close
+//
+// Safe for use across goroutines.
+type Scope struct {
+	handle.GeosHandleProvider
+	geoms    map[*Geometry]struct{}
+	prepared []*PreparedGeometry
+	// Options for Factory, when created by Factory.NewScope
+	factory Factory
+	sync.Mutex
+}
+
+// Creates a scope leasing handles from hp.
+func NewScope(hp handle.GeosHandleProvider) *Scope {
+	return &Scope{
+		GeosHandleProvider: hp,
+		geoms:              make(map[*Geometry]struct{}),
+	}
+}
+
+// Factory whose geometries are tracked by this scope. Has the same options as
+// the Factory which created the scope, if any.
+func (s *Scope) Factory() Factory {
+	f := s.factory
+	f.hp = s
+	return f
+}
+
+func (s *Scope) track(g *Geometry) {
+	s.Lock()
+	s.geoms[g] = struct{}{}
+	s.Unlock()
+}
+
+func (s *Scope) trackPrepared(pg *PreparedGeometry) {
+	s.Lock()
+	s.prepared = append(s.prepared, pg)
+	s.Unlock()
+}
+
+// Stops tracking g, leaving it to be managed as if it had been created outside
+// of the scope: by the GC, or by the enclosing scope if this scope was created
+// from another scope's Factory. Geometries derived from g after Keep is called
+// are not tracked by this scope either. Returns g for convenience.
+func (s *Scope) Keep(g *Geometry) *Geometry {
+	s.Lock()
+	delete(s.geoms, g)
+	s.Unlock()
+	g.hp.Store(providerRef{s.GeosHandleProvider})
+	if parent := trackerOf(s.GeosHandleProvider); parent != nil {
+		parent.track(g)
+	}
+	return g
+}
+
+// Frees every tracked geometry and prepared geometry. None of them may be used
+// afterwards. The scope may continue to be used, and Close called again.
+func (s *Scope) Close() {
+	s.Lock()
+	geoms, prepared := s.geoms, s.prepared
+	s.geoms, s.prepared = make(map[*Geometry]struct{}), nil
+	s.Unlock()
+
+	// Prepared geometries reference their parents, so must be freed first
+	for _, pg := range prepared {
+		pg.Close()
+	}
+	for g := range geoms {
+		g.Close()
+	}
+}
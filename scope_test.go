@@ -0,0 +1,144 @@
+package geom
+
+import (
+	"runtime"
+	"sync"
+	"testing"
+
+	"github.com/vistarmedia/geom/geos-go/handle"
+)
+
+func TestClose(t *testing.T) {
+	p, err := fact.NewPoint(Coord{1, 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	p.Close()
+	if p.UnsafeToGeos() != nil {
+		t.Error("Expected geometry to be released")
+	}
+	// Closing twice is harmless
+	p.Close()
+	runtime.GC()
+}
+
+func TestConcurrentClose(t *testing.T) {
+	scope := fact.NewScope()
+	p, err := scope.Factory().NewPoint(Coord{1, 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			p.Close()
+		}()
+		go func() {
+			defer wg.Done()
+			scope.Close()
+		}()
+	}
+	wg.Wait()
+	if p.UnsafeToGeos() != nil {
+		t.Error("Expected geometry to be released")
+	}
+}
+
+func TestInstrumentedScope(t *testing.T) {
+	scope := fact.NewScope()
+	ip := handle.NewInstrumentedHandleProvider(scope)
+	p, err := NewFactory(ip).NewPoint(Coord{1, 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	scope.Close()
+	if p.UnsafeToGeos() != nil {
+		t.Error("Expected the wrapped scope to track the geometry")
+	}
+}
+
+func TestPreparedClose(t *testing.T) {
+	poly, err := fact.NewPolygon([]Coord{{0, 0}, {1, 0}, {1, 1}, {0, 0}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	prep := poly.Prepared()
+	prep.Close()
+	prep.Close()
+
+	// The parent is still usable
+	if poly.Area() != 0.5 {
+		t.Errorf("Expected area of 0.5, got %f", poly.Area())
+	}
+}
+
+func TestScope(t *testing.T) {
+	scope := fact.NewScope()
+	sfact := scope.Factory()
+
+	square, err := sfact.NewPolygon([]Coord{
+		{0, 0},
+		{10, 0},
+		{10, 10},
+		{0, 10},
+		{0, 0},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	buffered, err := square.Buffer(1, 8)
+	if err != nil {
+		t.Fatal(err)
+	}
+	prep := buffered.Prepared()
+	kept, err := buffered.Envelope()
+	if err != nil {
+		t.Fatal(err)
+	}
+	scope.Keep(kept)
+
+	if len(scope.geoms) != 2 || len(scope.prepared) != 1 {
+		t.Fatalf("Expected 2 geometries and 1 prepared geometry, got %d and %d",
+			len(scope.geoms), len(scope.prepared))
+	}
+	scope.Close()
+
+	if square.UnsafeToGeos() != nil || buffered.UnsafeToGeos() != nil {
+		t.Error("Expected scoped geometries to be released")
+	}
+	if prep.p != nil {
+		t.Error("Expected prepared geometry to be released")
+	}
+	if kept.Area() != 144 {
+		t.Errorf("Expected kept geometry area of 144, got %f", kept.Area())
+	}
+
+	// Geometries derived from a kept geometry are not tracked
+	if _, err := kept.Buffer(1, 8); err != nil {
+		t.Fatal(err)
+	}
+	if len(scope.geoms) != 0 {
+		t.Errorf("Expected no tracked geometries, got %d", len(scope.geoms))
+	}
+}
+
+func TestNestedScope(t *testing.T) {
+	outer := fact.NewScope()
+	inner := outer.Factory().NewScope()
+
+	p, err := inner.Factory().NewPoint(Coord{1, 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	inner.Keep(p.Geometry)
+	inner.Close()
+	if p.UnsafeToGeos() == nil {
+		t.Fatal("Kept geometry should survive the inner scope")
+	}
+	outer.Close()
+	if p.UnsafeToGeos() != nil {
+		t.Error("Kept geometry should be released with the outer scope")
+	}
+}
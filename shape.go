@@ -0,0 +1,267 @@
+package geom
+
+import (
+	"runtime"
+
+	"github.com/vistarmedia/geom/geos-go"
+	"github.com/vistarmedia/geom/shape"
+)
+
+// Builds a geometry from a plain Go shape, applying the factory's precision
+// and SRID. LineStrings and LinearRings must not be empty.
+func (f Factory) FromShape(s shape.Shape) (*Geometry, error) {
+	h := f.hp.Get()
+	defer f.hp.Put(h)
+	g, err := shapeToGeos(h, s)
+	return f.newGeometryOrError(h, g, err)
+}
+
+// Copies the geometry out in to a plain Go shape
+func (g *Geometry) Shape() (shape.Shape, error) {
+	hp := g.provider()
+	h := hp.Get()
+	defer hp.Put(h)
+	defer runtime.KeepAlive(g)
+	return geosToShape(h, g.g)
+}
+
+func shapeToGeos(h *geos.Handle, s shape.Shape) (*geos.Geometry, error) {
+	switch s := s.(type) {
+	case shape.Point:
+		if s.IsEmpty() {
+			return geos.NewEmptyPoint(h), nil
+		}
+		cs, err := shapeCoordSeq(h, []shape.Coord{shape.Coord(s)})
+		if err != nil {
+			return nil, err
+		}
+		return cs.Point(h)
+
+	case shape.LineString:
+		return shapeLineString(h, s)
+
+	case shape.LinearRing:
+		return shapeLinearRing(h, s)
+
+	case shape.Polygon:
+		return polygonToGeos(h, s)
+
+	case shape.MultiPoint:
+		parts := make([]shape.Shape, len(s))
+		for i, p := range s {
+			parts[i] = p
+		}
+		return shapesToGeos(h, geos.MULTIPOINT, parts)
+
+	case shape.MultiLineString:
+		parts := make([]shape.Shape, len(s))
+		for i, ls := range s {
+			parts[i] = ls
+		}
+		return shapesToGeos(h, geos.MULTILINESTRING, parts)
+
+	case shape.MultiPolygon:
+		parts := make([]shape.Shape, len(s))
+		for i, p := range s {
+			parts[i] = p
+		}
+		return shapesToGeos(h, geos.MULTIPOLYGON, parts)
+
+	case shape.Collection:
+		return shapesToGeos(h, geos.GEOMETRYCOLLECTION, s)
+
+	default:
+		return nil, shape.ErrUnsupportedShape
+	}
+}
+
+func polygonToGeos(h *geos.Handle, p shape.Polygon) (*geos.Geometry, error) {
+	if len(p) == 0 {
+		return geos.NewEmptyPolygon(h), nil
+	}
+	shell, err := shapeLinearRing(h, p[0])
+	if err != nil {
+		return nil, err
+	}
+	holes := make([]*geos.Geometry, 0, len(p)-1)
+	for _, ring := range p[1:] {
+		hole, err := shapeLinearRing(h, ring)
+		if err != nil {
+			shell.Destroy(h)
+			for _, hole := range holes {
+				hole.Destroy(h)
+			}
+			return nil, err
+		}
+		holes = append(holes, hole)
+	}
+	// The polygon takes ownership of the rings, even on error
+	return geos.NewPolygon(h, shell, holes)
+}
+
+func shapesToGeos(h *geos.Handle, typeId geos.GeometryTypeId,
+	ss []shape.Shape) (*geos.Geometry, error) {
+
+	if len(ss) == 0 {
+		return geos.NewEmptyGeometryCollection(h, typeId), nil
+	}
+	parts := make([]*geos.Geometry, 0, len(ss))
+	for _, s := range ss {
+		part, err := shapeToGeos(h, s)
+		if err != nil {
+			for _, part := range parts {
+				part.Destroy(h)
+			}
+			return nil, err
+		}
+		parts = append(parts, part)
+	}
+	// The collection takes ownership of the parts, even on error
+	return geos.NewGeometryCollection(h, typeId, parts)
+}
+
+func geosToShape(h *geos.Handle, g *geos.Geometry) (shape.Shape, error) {
+	isEmpty, err := g.IsEmpty(h)
+	if err != nil {
+		return nil, err
+	}
+
+	switch typeId := g.TypeId(h); typeId {
+	case geos.POINT:
+		if isEmpty {
+			return shape.EmptyPoint(), nil
+		}
+		coords, err := geosShapeCoords(h, g)
+		if err != nil {
+			return nil, err
+		}
+		return shape.Point(coords[0]), nil
+
+	case geos.LINESTRING:
+		coords, err := geosShapeCoords(h, g)
+		return shape.LineString(coords), err
+
+	case geos.LINEARRING:
+		coords, err := geosShapeCoords(h, g)
+		return shape.LinearRing(coords), err
+
+	case geos.POLYGON:
+		if isEmpty {
+			return shape.Polygon{}, nil
+		}
+		return geosToPolygon(h, g)
+
+	default:
+		n, err := g.NumGeometries(h)
+		if err != nil {
+			return nil, err
+		}
+		parts := make(shape.Collection, n)
+		for i := range parts {
+			owned, err := g.GeometryN(h, i)
+			if err != nil {
+				return nil, err
+			}
+			if parts[i], err = geosToShape(h, owned); err != nil {
+				return nil, err
+			}
+		}
+		return collectionShape(typeId, parts), nil
+	}
+}
+
+func geosToPolygon(h *geos.Handle, g *geos.Geometry) (shape.Polygon, error) {
+	ring, err := g.ExteriorRing(h)
+	if err != nil {
+		return nil, err
+	}
+	shell, err := geosShapeCoords(h, ring)
+	if err != nil {
+		return nil, err
+	}
+	numHoles, err := g.NumInteriorRings(h)
+	if err != nil {
+		return nil, err
+	}
+	p := make(shape.Polygon, 1, numHoles+1)
+	p[0] = shell
+	for i := 0; i < numHoles; i++ {
+		ring, err := g.InteriorRingN(h, i)
+		if err != nil {
+			return nil, err
+		}
+		hole, err := geosShapeCoords(h, ring)
+		if err != nil {
+			return nil, err
+		}
+		p = append(p, hole)
+	}
+	return p, nil
+}
+
+// Narrows the members of a GEOS collection to the shape type for typeId
+func collectionShape(typeId geos.GeometryTypeId, parts shape.Collection) shape.Shape {
+	switch typeId {
+	case geos.MULTIPOINT:
+		mp := make(shape.MultiPoint, len(parts))
+		for i, part := range parts {
+			mp[i] = part.(shape.Point)
+		}
+		return mp
+	case geos.MULTILINESTRING:
+		mls := make(shape.MultiLineString, len(parts))
+		for i, part := range parts {
+			mls[i] = part.(shape.LineString)
+		}
+		return mls
+	case geos.MULTIPOLYGON:
+		mp := make(shape.MultiPolygon, len(parts))
+		for i, part := range parts {
+			mp[i] = part.(shape.Polygon)
+		}
+		return mp
+	default:
+		return parts
+	}
+}
+
+func shapeCoordSeq(h *geos.Handle, coords []shape.Coord) (*geos.CoordSeq, error) {
+	if len(coords) == 0 {
+		return nil, ErrEmptyCoords
+	}
+	xy := make([]float64, 2*len(coords))
+	for i, c := range coords {
+		xy[2*i] = c.X
+		xy[2*i+1] = c.Y
+	}
+	return geos.NewCoordSeqFromXY(h, xy)
+}
+
+func shapeLinearRing(h *geos.Handle, coords []shape.Coord) (*geos.Geometry, error) {
+	cs, err := shapeCoordSeq(h, coords)
+	if err != nil {
+		return nil, err
+	}
+	// LinearRing destructor will destroy the coord seq even on error
+	return cs.LinearRing(h)
+}
+
+func shapeLineString(h *geos.Handle, coords []shape.Coord) (*geos.Geometry, error) {
+	cs, err := shapeCoordSeq(h, coords)
+	if err != nil {
+		return nil, err
+	}
+	return cs.LineString(h)
+}
+
+func geosShapeCoords(h *geos.Handle, g *geos.Geometry) ([]shape.Coord, error) {
+	xy, err := geosXY(h, g)
+	if err != nil || xy == nil {
+		return nil, err
+	}
+	coords := make([]shape.Coord, len(xy)/2)
+	for i := range coords {
+		coords[i] = shape.Coord{X: xy[2*i], Y: xy[2*i+1]}
+	}
+	return coords, nil
+}
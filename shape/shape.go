@@ -0,0 +1,228 @@
+// Plain Go geometry values. Unlike geom.Geometry, shapes hold no libgeos
+// memory, so they can be built, compared and serialized without cgo. Convert
+// them with geom.Factory.FromShape and geom.Geometry.Shape to run GEOS
+// operations on them.
+package shape
+
+import (
+	"errors"
+	"math"
+)
+
+var (
+	ErrUnsupportedShape = errors.New("Unsupported shape")
+)
+
+type Coord struct {
+	X, Y float64
+}
+
+// One of Point, LineString, LinearRing, Polygon, MultiPoint, MultiLineString,
+// MultiPolygon or Collection
+type Shape interface {
+	// Bounding box of every coordinate in the shape. ok is false when the shape
+	// is empty.
+	Bounds() (min, max Coord, ok bool)
+}
+
+// A NaN Point is empty, as in WKB
+type Point Coord
+
+type LineString []Coord
+
+// Closed LineString
+type LinearRing []Coord
+
+// Shell followed by any holes. An empty Polygon has no rings.
+type Polygon []LinearRing
+
+type MultiPoint []Point
+
+type MultiLineString []LineString
+
+type MultiPolygon []Polygon
+
+type Collection []Shape
+
+func EmptyPoint() Point {
+	return Point{math.NaN(), math.NaN()}
+}
+
+func (p Point) IsEmpty() bool {
+	return math.IsNaN(p.X) && math.IsNaN(p.Y)
+}
+
+func (p Point) Bounds() (min, max Coord, ok bool) {
+	if p.IsEmpty() {
+		return
+	}
+	return Coord(p), Coord(p), true
+}
+
+func (ls LineString) Bounds() (min, max Coord, ok bool) {
+	return coordBounds(ls)
+}
+
+func (lr LinearRing) Bounds() (min, max Coord, ok bool) {
+	return coordBounds(lr)
+}
+
+func (p Polygon) Bounds() (min, max Coord, ok bool) {
+	// Holes lie within the shell
+	if len(p) == 0 {
+		return
+	}
+	return coordBounds(p[0])
+}
+
+func (mp MultiPoint) Bounds() (min, max Coord, ok bool) {
+	b := bounds{}
+	for _, p := range mp {
+		b.add(p.Bounds())
+	}
+	return b.min, b.max, b.ok
+}
+
+func (mls MultiLineString) Bounds() (min, max Coord, ok bool) {
+	b := bounds{}
+	for _, ls := range mls {
+		b.add(ls.Bounds())
+	}
+	return b.min, b.max, b.ok
+}
+
+func (mp MultiPolygon) Bounds() (min, max Coord, ok bool) {
+	b := bounds{}
+	for _, p := range mp {
+		b.add(p.Bounds())
+	}
+	return b.min, b.max, b.ok
+}
+
+func (c Collection) Bounds() (min, max Coord, ok bool) {
+	b := bounds{}
+	for _, s := range c {
+		b.add(s.Bounds())
+	}
+	return b.min, b.max, b.ok
+}
+
+// Exact structural equality. Unlike geom.Geometry.Equals, the coordinates and
+// their order must match, and shapes of different types are never equal.
+func Equal(a, b Shape) bool {
+	switch a := a.(type) {
+	case Point:
+		b, ok := b.(Point)
+		return ok && equalPoint(a, b)
+	case LineString:
+		b, ok := b.(LineString)
+		return ok && equalCoords(a, b)
+	case LinearRing:
+		b, ok := b.(LinearRing)
+		return ok && equalCoords(a, b)
+	case Polygon:
+		b, ok := b.(Polygon)
+		return ok && equalPolygon(a, b)
+	case MultiPoint:
+		b, ok := b.(MultiPoint)
+		if !ok || len(a) != len(b) {
+			return false
+		}
+		for i := range a {
+			if !equalPoint(a[i], b[i]) {
+				return false
+			}
+		}
+		return true
+	case MultiLineString:
+		b, ok := b.(MultiLineString)
+		if !ok || len(a) != len(b) {
+			return false
+		}
+		for i := range a {
+			if !equalCoords(a[i], b[i]) {
+				return false
+			}
+		}
+		return true
+	case MultiPolygon:
+		b, ok := b.(MultiPolygon)
+		if !ok || len(a) != len(b) {
+			return false
+		}
+		for i := range a {
+			if !equalPolygon(a[i], b[i]) {
+				return false
+			}
+		}
+		return true
+	case Collection:
+		b, ok := b.(Collection)
+		if !ok || len(a) != len(b) {
+			return false
+		}
+		for i := range a {
+			if !Equal(a[i], b[i]) {
+				return false
+			}
+		}
+		return true
+	default:
+		return false
+	}
+}
+
+func equalPoint(a, b Point) bool {
+	return a == b || (a.IsEmpty() && b.IsEmpty())
+}
+
+func equalCoords(a, b []Coord) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func equalPolygon(a, b Polygon) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !equalCoords(a[i], b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+type bounds struct {
+	min, max Coord
+	ok       bool
+}
+
+func (b *bounds) add(min, max Coord, ok bool) {
+	if !ok {
+		return
+	}
+	if !b.ok {
+		b.min, b.max, b.ok = min, max, true
+		return
+	}
+	b.min.X = math.Min(b.min.X, min.X)
+	b.min.Y = math.Min(b.min.Y, min.Y)
+	b.max.X = math.Max(b.max.X, max.X)
+	b.max.Y = math.Max(b.max.Y, max.Y)
+}
+
+func coordBounds(coords []Coord) (min, max Coord, ok bool) {
+	b := bounds{}
+	for _, c := range coords {
+		b.add(c, c, true)
+	}
+	return b.min, b.max, b.ok
+}
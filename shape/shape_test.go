@@ -0,0 +1,58 @@
+package shape
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+var square = Polygon{
+	{{0, 0}, {4, 0}, {4, 4}, {0, 4}, {0, 0}},
+	{{1, 1}, {2, 1}, {2, 2}, {1, 1}},
+}
+
+func TestBounds(t *testing.T) {
+	min, max, ok := Collection{
+		Point{-1, 2},
+		MultiPolygon{square},
+		MultiPoint{EmptyPoint()},
+	}.Bounds()
+	if !ok {
+		t.Fatal("Expected bounds")
+	}
+	if min != (Coord{-1, 0}) || max != (Coord{4, 4}) {
+		t.Errorf("Unexpected bounds %v %v", min, max)
+	}
+
+	if _, _, ok := (Collection{EmptyPoint(), Polygon{}}).Bounds(); ok {
+		t.Error("Expected empty collection to have no bounds")
+	}
+}
+
+func TestEqual(t *testing.T) {
+	if !Equal(EmptyPoint(), EmptyPoint()) {
+		t.Error("Expected empty points to be equal")
+	}
+	if !Equal(Collection{square, Point{1, 2}}, Collection{square, Point{1, 2}}) {
+		t.Error("Expected equal collections")
+	}
+	if Equal(LineString(square[0]), LinearRing(square[0])) {
+		t.Error("Expected different types to differ")
+	}
+	if Equal(square, Polygon{square[0]}) {
+		t.Error("Expected polygon without hole to differ")
+	}
+}
+
+func TestJSON(t *testing.T) {
+	b, err := json.Marshal(MultiPolygon{square})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded MultiPolygon
+	if err := json.Unmarshal(b, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if !Equal(decoded, MultiPolygon{square}) {
+		t.Errorf("Expected %v, got %v", square, decoded)
+	}
+}
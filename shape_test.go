@@ -0,0 +1,56 @@
+package geom
+
+import (
+	"testing"
+
+	"github.com/vistarmedia/geom/shape"
+)
+
+func TestShapeRoundTrip(t *testing.T) {
+	square := shape.Polygon{
+		{{0, 0}, {4, 0}, {4, 4}, {0, 4}, {0, 0}},
+		{{1, 1}, {2, 1}, {2, 2}, {1, 1}},
+	}
+	shapes := []shape.Shape{
+		shape.Point{1, 2},
+		shape.EmptyPoint(),
+		shape.LineString{{0, 0}, {1, 1}},
+		square,
+		shape.Polygon{},
+		shape.MultiPoint{{1, 2}, {3, 4}},
+		shape.MultiLineString{{{0, 0}, {1, 1}}},
+		shape.MultiPolygon{square},
+		shape.MultiPolygon{},
+		shape.Collection{shape.Point{1, 2}, square},
+	}
+	for _, s := range shapes {
+		g, err := fact.FromShape(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		back, err := g.Shape()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !shape.Equal(s, back) {
+			t.Errorf("Expected %v, got %v", s, back)
+		}
+	}
+}
+
+func TestFromShapeTypes(t *testing.T) {
+	g, err := fact.FromShape(shape.MultiPolygon{{{{0, 0}, {1, 0}, {0, 1}, {0, 0}}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if g.Type() != MULTIPOLYGON {
+		t.Errorf("Unexpected geom type: %d", g.Type())
+	}
+	if area := g.Area(); area != 0.5 {
+		t.Errorf("Expected area 0.5, got %f", area)
+	}
+
+	if _, err := fact.FromShape(shape.LineString{}); err != ErrEmptyCoords {
+		t.Errorf("Expected ErrEmptyCoords, got %v", err)
+	}
+}
@@ -0,0 +1,118 @@
+package geom
+
+import (
+	"container/heap"
+	"math"
+)
+
+// Simplifies a line with the Visvalingam-Whyatt algorithm. Vertices are
+// repeatedly removed, smallest first, while the triangle they form with their
+// neighbors has an area less than minArea. The first and last coordinates are
+// always kept. Closed rings, such as those returned from Polygon.Shell and
+// Polygon.Holes, will never be reduced below 4 coordinates so the result can
+// still be used to construct a LinearRing.
+//
+// Unlike Geometry.Simplify, this operates purely on Go slices and does not call
+// in to libgeos. The passed slice is not modified.
+func SimplifyVisvalingam(coords []Coord, minArea float64) []Coord {
+	n := len(coords)
+	if n < 3 {
+		return append([]Coord(nil), coords...)
+	}
+
+	minPoints := 2
+	if coords[0] == coords[n-1] {
+		minPoints = 4
+	}
+
+	points := make([]vwPoint, n)
+	for i := range points {
+		points[i] = vwPoint{idx: i, prev: i - 1, next: i + 1}
+	}
+
+	queue := make(vwQueue, 0, n-2)
+	for i := 1; i < n-1; i++ {
+		points[i].area = triangleArea(coords[i-1], coords[i], coords[i+1])
+		points[i].heapIdx = len(queue)
+		queue = append(queue, &points[i])
+	}
+	heap.Init(&queue)
+
+	remaining := n
+	for queue.Len() > 0 && remaining > minPoints {
+		p := heap.Pop(&queue).(*vwPoint)
+		if p.area >= minArea {
+			break
+		}
+		p.removed = true
+		remaining--
+
+		prev, next := &points[p.prev], &points[p.next]
+		prev.next = p.next
+		next.prev = p.prev
+
+		// A neighbor's area can never drop below the area of the point just
+		// removed, otherwise it would be eliminated before points which were
+		// visited earlier.
+		if prev.prev >= 0 {
+			area := triangleArea(coords[prev.prev], coords[prev.idx], coords[next.idx])
+			queue.update(prev, math.Max(area, p.area))
+		}
+		if next.next < n {
+			area := triangleArea(coords[prev.idx], coords[next.idx], coords[next.next])
+			queue.update(next, math.Max(area, p.area))
+		}
+	}
+
+	simplified := make([]Coord, 0, remaining)
+	for i := range points {
+		if !points[i].removed {
+			simplified = append(simplified, coords[i])
+		}
+	}
+	return simplified
+}
+
+func triangleArea(a, b, c Coord) float64 {
+	return math.Abs((a.X*(b.Y-c.Y) + b.X*(c.Y-a.Y) + c.X*(a.Y-b.Y)) / 2)
+}
+
+type vwPoint struct {
+	idx        int
+	prev, next int
+	area       float64
+	heapIdx    int
+	removed    bool
+}
+
+// Min-heap of points ordered by effective area
+type vwQueue []*vwPoint
+
+func (q vwQueue) Len() int { return len(q) }
+
+func (q vwQueue) Less(i, j int) bool { return q[i].area < q[j].area }
+
+func (q vwQueue) Swap(i, j int) {
+	q[i], q[j] = q[j], q[i]
+	q[i].heapIdx = i
+	q[j].heapIdx = j
+}
+
+func (q *vwQueue) Push(x interface{}) {
+	p := x.(*vwPoint)
+	p.heapIdx = len(*q)
+	*q = append(*q, p)
+}
+
+func (q *vwQueue) Pop() interface{} {
+	old := *q
+	n := len(old)
+	p := old[n-1]
+	*q = old[:n-1]
+	return p
+}
+
+func (q *vwQueue) update(p *vwPoint, area float64) {
+	p.area = area
+	heap.Fix(q, p.heapIdx)
+}
@@ -0,0 +1,91 @@
+package geom
+
+import (
+	"testing"
+)
+
+func TestSimplifyVisvalingamLine(t *testing.T) {
+	coords := []Coord{
+		{0, 0},
+		{1, 0.1},
+		{2, 0},
+		{3, 5},
+		{4, 0},
+	}
+	simplified := SimplifyVisvalingam(coords, 1)
+	exp := []Coord{{0, 0}, {2, 0}, {3, 5}, {4, 0}}
+	if !compareCoordSlice(exp, simplified) {
+		t.Errorf("Expected %v, got %v", exp, simplified)
+	}
+	if len(coords) != 5 {
+		t.Errorf("Input should not be modified")
+	}
+}
+
+func TestSimplifyVisvalingamKeepsEndpoints(t *testing.T) {
+	coords := []Coord{{0, 0}, {1, 0}, {2, 0}, {3, 0}}
+	simplified := SimplifyVisvalingam(coords, 100)
+	exp := []Coord{{0, 0}, {3, 0}}
+	if !compareCoordSlice(exp, simplified) {
+		t.Errorf("Expected %v, got %v", exp, simplified)
+	}
+}
+
+func TestSimplifyVisvalingamRing(t *testing.T) {
+	shell := []Coord{
+		{0, 0},
+		{5, 0.01},
+		{10, 0},
+		{10, 10},
+		{0, 10},
+		{0, 0},
+	}
+	simplified := SimplifyVisvalingam(shell, 1)
+	exp := []Coord{{0, 0}, {10, 0}, {10, 10}, {0, 10}, {0, 0}}
+	if !compareCoordSlice(exp, simplified) {
+		t.Errorf("Expected %v, got %v", exp, simplified)
+	}
+
+	// A ring is never reduced below a valid LinearRing
+	simplified = SimplifyVisvalingam(shell, 1000)
+	if len(simplified) != 4 {
+		t.Fatalf("Expected 4 coords, got %v", simplified)
+	}
+	if _, err := fact.NewLinearRing(simplified); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestSimplify(t *testing.T) {
+	poly, err := fact.NewPolygon([]Coord{
+		{0, 0},
+		{5, 0.01},
+		{10, 0},
+		{10, 10},
+		{0, 10},
+		{0, 0},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	simple, err := poly.Simplify(0.1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	shell, err := simple.Polygon().Shell()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(shell) != 5 {
+		t.Errorf("Expected 5 coords, got %v", shell)
+	}
+
+	preserved, err := poly.TopologyPreserveSimplify(0.1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if preserved.Area() != simple.Area() {
+		t.Errorf("Expected area %f, got %f", simple.Area(), preserved.Area())
+	}
+}
@@ -0,0 +1,110 @@
+package geom
+
+import (
+	"expvar"
+	"sync/atomic"
+
+	"github.com/vistarmedia/geom/geos-go"
+)
+
+const (
+	// Rough libgeos footprint of a geometry object and of each coordinate,
+	// which GEOS always stores with three dimensions
+	geometryOverhead = 64
+	coordinateSize   = 24
+)
+
+// Counts of libgeos objects owned by this package. GEOS allocations are not
+// visible to the Go heap profiler, so these are the only view of how much
+// native memory geometries hold.
+type MemStats struct {
+	// Geometries created and not yet freed by Close or the GC
+	LiveGeometries int64
+	// Prepared geometries created and not yet freed by Close or the GC
+	LivePreparedGeometries int64
+	// Approximate native memory held by live geometries and prepared
+	// geometries, based on their coordinate counts. Only geometries created
+	// while MeasureNativeBytes is enabled are counted.
+	NativeBytes int64
+	// Geometries created over the life of the process
+	TotalGeometries int64
+	// Prepared geometries created over the life of the process
+	TotalPreparedGeometries int64
+}
+
+type memStats struct {
+	liveGeometries          int64
+	livePreparedGeometries  int64
+	nativeBytes             int64
+	totalGeometries         int64
+	totalPreparedGeometries int64
+}
+
+var (
+	stats memStats
+	// Non-zero when geometry sizes are measured
+	measureNative int32
+)
+
+// Enables or disables measuring the size of each new geometry for
+// MemStats.NativeBytes. Disabled by default, as measuring walks every
+// coordinate of every geometry created, making creation O(n) even for
+// operations which are otherwise cheaper.
+func MeasureNativeBytes(enabled bool) {
+	var flag int32
+	if enabled {
+		flag = 1
+	}
+	atomic.StoreInt32(&measureNative, flag)
+}
+
+func init() {
+	expvar.Publish("geom", expvar.Func(func() interface{} {
+		return Stats()
+	}))
+}
+
+// Snapshot of native memory usage. Also published with expvar as "geom".
+func Stats() MemStats {
+	return MemStats{
+		LiveGeometries:          atomic.LoadInt64(&stats.liveGeometries),
+		LivePreparedGeometries:  atomic.LoadInt64(&stats.livePreparedGeometries),
+		NativeBytes:             atomic.LoadInt64(&stats.nativeBytes),
+		TotalGeometries:         atomic.LoadInt64(&stats.totalGeometries),
+		TotalPreparedGeometries: atomic.LoadInt64(&stats.totalPreparedGeometries),
+	}
+}
+
+func (s *memStats) allocGeometry(size int64) {
+	atomic.AddInt64(&s.liveGeometries, 1)
+	atomic.AddInt64(&s.totalGeometries, 1)
+	atomic.AddInt64(&s.nativeBytes, size)
+}
+
+func (s *memStats) freeGeometry(size int64) {
+	atomic.AddInt64(&s.liveGeometries, -1)
+	atomic.AddInt64(&s.nativeBytes, -size)
+}
+
+func (s *memStats) allocPrepared(size int64) {
+	atomic.AddInt64(&s.livePreparedGeometries, 1)
+	atomic.AddInt64(&s.totalPreparedGeometries, 1)
+	atomic.AddInt64(&s.nativeBytes, size)
+}
+
+func (s *memStats) freePrepared(size int64) {
+	atomic.AddInt64(&s.livePreparedGeometries, -1)
+	atomic.AddInt64(&s.nativeBytes, -size)
+}
+
+// Zero unless MeasureNativeBytes is enabled
+func nativeSize(h *geos.Handle, g *geos.Geometry) int64 {
+	if atomic.LoadInt32(&measureNative) == 0 {
+		return 0
+	}
+	n, err := g.NumCoordinates(h)
+	if err != nil {
+		n = 0
+	}
+	return geometryOverhead + int64(n)*coordinateSize
+}
@@ -0,0 +1,173 @@
+package geom
+
+import (
+	"errors"
+	"math"
+	"runtime"
+	"sync"
+
+	"github.com/vistarmedia/geom/geos-go"
+	"github.com/vistarmedia/geom/geos-go/handle"
+)
+
+var (
+	ErrTreeBuilt = errors.New("STRtree has already been built")
+)
+
+// A geometry indexed by an STRtree along with an arbitrary value
+type STRtreeItem struct {
+	Geometry *Geometry
+	Value    interface{}
+}
+
+// Sort-Tile-Recursive packed R-tree indexing geometries by their envelopes. All
+// items must be inserted before the tree is built or queried, after which it
+// is read only. Safe for use across goroutines.
+type STRtree struct {
+	hp handle.GeosHandleProvider
+	t  *geos.STRtree
+	// GEOS items are indices in to this slice, offset by one. Holding the
+	// geometries here also keeps them alive while GEOS references them.
+	items []STRtreeItem
+	built bool
+	sync.Mutex
+}
+
+func newSTRtree(hp handle.GeosHandleProvider, nodeCapacity uint) *STRtree {
+	h := hp.Get()
+	t := geos.NewSTRtree(h, nodeCapacity)
+	hp.Put(h)
+	tree := &STRtree{
+		hp: hp,
+		t:  t,
+	}
+	runtime.SetFinalizer(tree, func(tree1 *STRtree) {
+		handle.Finalize(tree1.t.Destroy)
+	})
+	return tree
+}
+
+// Number of items in the tree
+func (t *STRtree) Len() int {
+	t.Lock()
+	defer t.Unlock()
+	return len(t.items)
+}
+
+// Adds g to the tree, associated with value.
+func (t *STRtree) Insert(g *Geometry, value interface{}) error {
+	t.Lock()
+	defer t.Unlock()
+	if t.built {
+		return ErrTreeBuilt
+	}
+
+	h := t.hp.Get()
+	t.items = append(t.items, STRtreeItem{Geometry: g, Value: value})
+	t.t.Insert(h, g.g, uint(len(t.items)))
+	t.hp.Put(h)
+	return nil
+}
+
+// Builds the tree, after which no more items may be inserted. The tree is
+// otherwise built on its first query.
+func (t *STRtree) Build() error {
+	t.Lock()
+	defer t.Unlock()
+
+	h := t.hp.Get()
+	defer t.hp.Put(h)
+	if err := t.t.Build(h); err != nil {
+		return err
+	}
+	t.built = true
+	return nil
+}
+
+// All items whose envelopes intersect the envelope of g. The geometries
+// themselves may not intersect.
+func (t *STRtree) Query(g toGeos) []STRtreeItem {
+	t.Lock()
+	defer t.Unlock()
+
+	h := t.hp.Get()
+	var items []STRtreeItem
+	t.t.Query(h, g.UnsafeToGeos(), func(item uint) {
+		items = append(items, t.items[item-1])
+	})
+	runtime.KeepAlive(g)
+	t.hp.Put(h)
+	t.built = true
+	return items
+}
+
+// All items whose envelopes intersect the box with corners c0 and c1, as
+// returned by Geometry.Bounds.
+func (t *STRtree) QueryBounds(c0, c1 Coord) ([]STRtreeItem, error) {
+	return t.QueryEnvelope(Envelope{c0.X, c0.Y, c1.X, c1.Y})
+}
+
+// All items whose envelopes intersect env.
+func (t *STRtree) QueryEnvelope(env Envelope) ([]STRtreeItem, error) {
+	poly, err := NewFactory(t.hp).NewPolygon([]Coord{
+		{X: env.MinX, Y: env.MinY},
+		{X: env.MaxX, Y: env.MinY},
+		{X: env.MaxX, Y: env.MaxY},
+		{X: env.MinX, Y: env.MaxY},
+		{X: env.MinX, Y: env.MinY},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return t.Query(poly), nil
+}
+
+// The k items nearest to g, closest first. Distance is measured between the
+// geometries, not their envelopes. Fewer than k items are returned if the tree
+// does not have k items.
+func (t *STRtree) Nearest(g toGeos, k int) ([]STRtreeItem, error) {
+	t.Lock()
+	defer t.Unlock()
+	defer runtime.KeepAlive(g)
+
+	h := t.hp.Get()
+	defer t.hp.Put(h)
+	t.built = true
+
+	// GEOS only finds the single nearest item. Each subsequent neighbor is found
+	// by searching again with the previous results pushed infinitely far away.
+	found := make(map[uint]bool)
+	distance := func(item uint) (float64, error) {
+		if found[item] {
+			return math.Inf(1), nil
+		}
+		return t.items[item-1].Geometry.g.Distance(h, g.UnsafeToGeos())
+	}
+
+	var items []STRtreeItem
+	for len(items) < k && len(items) < len(t.items) {
+		item, err := t.t.Nearest(h, g.UnsafeToGeos(), distance)
+		if err != nil {
+			return nil, err
+		}
+		if item == 0 || found[item] {
+			break
+		}
+		found[item] = true
+		items = append(items, t.items[item-1])
+	}
+	return items, nil
+}
+
+// Calls fn with every item in the tree. The tree is locked for the duration, so
+// fn must not call back in to it.
+func (t *STRtree) Each(fn func(STRtreeItem)) {
+	t.Lock()
+	defer t.Unlock()
+
+	h := t.hp.Get()
+	defer t.hp.Put(h)
+	t.t.Iterate(h, func(item uint) {
+		fn(t.items[item-1])
+	})
+}
@@ -0,0 +1,96 @@
+package geom
+
+import (
+	"testing"
+)
+
+func pointTree(t *testing.T) *STRtree {
+	tree := fact.NewSTRtree(10)
+	for i := 0; i < 10; i++ {
+		p, err := fact.NewPoint(Coord{float64(i), float64(i)})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := tree.Insert(p.Geometry, i); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return tree
+}
+
+func TestSTRtreeQuery(t *testing.T) {
+	tree := pointTree(t)
+	items, err := tree.QueryBounds(Coord{2.5, 2.5}, Coord{5, 5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	found := make(map[int]bool)
+	for _, item := range items {
+		found[item.Value.(int)] = true
+	}
+	if len(found) != 3 || !found[3] || !found[4] || !found[5] {
+		t.Errorf("Expected items 3, 4 and 5, got %v", found)
+	}
+
+	p, err := fact.NewPoint(Coord{20, 20})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if items := tree.Query(p); len(items) != 0 {
+		t.Errorf("Expected no items, got %v", items)
+	}
+}
+
+func TestSTRtreeInsertAfterQuery(t *testing.T) {
+	tree := pointTree(t)
+	p, err := fact.NewPoint(Coord{20, 20})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tree.Query(p)
+	if err := tree.Insert(p.Geometry, 20); err != ErrTreeBuilt {
+		t.Errorf("Expected %v, got %v", ErrTreeBuilt, err)
+	}
+}
+
+func TestSTRtreeNearest(t *testing.T) {
+	tree := pointTree(t)
+	p, err := fact.NewPoint(Coord{6.2, 6.2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	items, err := tree.Nearest(p, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(items) != 3 {
+		t.Fatalf("Expected 3 items, got %d", len(items))
+	}
+	for i, exp := range []int{6, 7, 5} {
+		if items[i].Value != exp {
+			t.Errorf("Expected item %d to be %d, got %v", i, exp, items[i].Value)
+		}
+	}
+
+	items, err = tree.Nearest(p, 20)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(items) != 10 {
+		t.Errorf("Expected all 10 items, got %d", len(items))
+	}
+}
+
+func TestSTRtreeEach(t *testing.T) {
+	tree := pointTree(t)
+	sum := 0
+	tree.Each(func(item STRtreeItem) {
+		sum += item.Value.(int)
+	})
+	if sum != 45 {
+		t.Errorf("Expected sum of 45, got %d", sum)
+	}
+	if tree.Len() != 10 {
+		t.Errorf("Expected 10 items, got %d", tree.Len())
+	}
+}
@@ -0,0 +1,144 @@
+package geom
+
+import (
+	"runtime"
+
+	"github.com/vistarmedia/geom/geos-go"
+)
+
+// Location of a component within the geometry being walked
+type Path struct {
+	// Index of the component within each enclosing collection, outermost first.
+	// Empty for the walked geometry itself.
+	Members []int
+	// 0 for a polygon's shell and 1 onwards for its holes. -1 for coordinates of
+	// points and lines.
+	Ring int
+}
+
+// Receives the components of a geometry from Walk. Paths are reused between
+// calls and must be copied to be retained.
+type Visitor interface {
+	// Called for each geometry, collections before their members. Returning
+	// false skips the geometry's members and coordinates.
+	VisitGeometry(path Path, t GeometryType) bool
+	// Called for the ith coordinate of a point, line or polygon ring. Returning
+	// false stops the walk.
+	VisitCoord(path Path, i int, c Coord) bool
+}
+
+// Traverses every component, ring and coordinate of g depth first, reading
+// coordinates directly from GEOS. A single handle is leased for the whole walk,
+// so like WithHandle, v must not call methods on Geometry.
+func (g *Geometry) Walk(v Visitor) error {
+	hp := g.provider()
+	h := hp.Get()
+	defer hp.Put(h)
+	defer runtime.KeepAlive(g)
+	w := walker{h: h, v: v}
+	_, err := w.walk(g.g, Path{Ring: -1})
+	return err
+}
+
+// Calls fn for every coordinate of g in the order visited by Walk, until fn
+// returns false.
+func (g *Geometry) EachCoord(fn func(path Path, i int, c Coord) bool) error {
+	return g.Walk(coordVisitor(fn))
+}
+
+type coordVisitor func(path Path, i int, c Coord) bool
+
+func (coordVisitor) VisitGeometry(Path, GeometryType) bool {
+	return true
+}
+
+func (fn coordVisitor) VisitCoord(path Path, i int, c Coord) bool {
+	return fn(path, i, c)
+}
+
+type walker struct {
+	h *geos.Handle
+	v Visitor
+	// Reused across coordinate sequences
+	xy []float64
+}
+
+// Returns false if the visitor stopped the walk
+func (w *walker) walk(g *geos.Geometry, path Path) (bool, error) {
+	h, v := w.h, w.v
+	t := geometryType(g.TypeId(h))
+	if !v.VisitGeometry(path, t) {
+		return true, nil
+	}
+	if isEmpty, err := g.IsEmpty(h); err != nil || isEmpty {
+		return true, err
+	}
+
+	switch t {
+	case POINT, LINESTRING, LINEARRING:
+		return w.walkCoords(g, path)
+
+	case POLYGON:
+		shell, err := g.ExteriorRing(h)
+		if err != nil {
+			return false, err
+		}
+		path.Ring = 0
+		if ok, err := w.walkCoords(shell, path); !ok || err != nil {
+			return ok, err
+		}
+		numHoles, err := g.NumInteriorRings(h)
+		if err != nil {
+			return false, err
+		}
+		for i := 0; i < numHoles; i++ {
+			hole, err := g.InteriorRingN(h, i)
+			if err != nil {
+				return false, err
+			}
+			path.Ring = i + 1
+			if ok, err := w.walkCoords(hole, path); !ok || err != nil {
+				return ok, err
+			}
+		}
+		return true, nil
+
+	default:
+		n, err := g.NumGeometries(h)
+		if err != nil {
+			return false, err
+		}
+		for i := 0; i < n; i++ {
+			member, err := g.GeometryN(h, i)
+			if err != nil {
+				return false, err
+			}
+			memberPath := Path{Members: append(path.Members, i), Ring: -1}
+			if ok, err := w.walk(member, memberPath); !ok || err != nil {
+				return ok, err
+			}
+		}
+		return true, nil
+	}
+}
+
+func (w *walker) walkCoords(g *geos.Geometry, path Path) (bool, error) {
+	cs, err := g.CoordSeq(w.h)
+	if err != nil {
+		return false, err
+	}
+	size := int(cs.Size(w.h))
+	if cap(w.xy) < 2*size {
+		w.xy = make([]float64, 2*size)
+	}
+	xy := w.xy[:2*size]
+	if err := cs.CopyXY(w.h, xy); err != nil {
+		return false, err
+	}
+	for i := 0; i < size; i++ {
+		if !w.v.VisitCoord(path, i, Coord{X: xy[2*i], Y: xy[2*i+1]}) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
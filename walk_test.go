@@ -0,0 +1,95 @@
+package geom
+
+import (
+	"reflect"
+	"testing"
+)
+
+type recordingVisitor struct {
+	types  []GeometryType
+	rings  []int
+	coords []Coord
+	paths  [][]int
+	skip   GeometryType
+}
+
+func (v *recordingVisitor) VisitGeometry(path Path, t GeometryType) bool {
+	v.types = append(v.types, t)
+	v.paths = append(v.paths, append([]int{}, path.Members...))
+	return t != v.skip
+}
+
+func (v *recordingVisitor) VisitCoord(path Path, i int, c Coord) bool {
+	v.rings = append(v.rings, path.Ring)
+	v.coords = append(v.coords, c)
+	return true
+}
+
+func walkTestCollection(t *testing.T) *Geometry {
+	p, err := fact.NewPoint(Coord{1, 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	poly, err := fact.NewPolygon(
+		[]Coord{{0, 0}, {4, 0}, {4, 4}, {0, 0}},
+		[]Coord{{1, 1}, {2, 1}, {2, 2}, {1, 1}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	mp, err := fact.NewMultipolygon(poly)
+	if err != nil {
+		t.Fatal(err)
+	}
+	coll, err := fact.NewGeometryCollection(p.Geometry, mp.Geometry)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return coll
+}
+
+func TestWalk(t *testing.T) {
+	v := &recordingVisitor{skip: -1}
+	if err := walkTestCollection(t).Walk(v); err != nil {
+		t.Fatal(err)
+	}
+
+	expTypes := []GeometryType{GEOMETRYCOLLECTION, POINT, MULTIPOLYGON, POLYGON}
+	if !reflect.DeepEqual(v.types, expTypes) {
+		t.Errorf("Expected types %v, got %v", expTypes, v.types)
+	}
+	expPaths := [][]int{{}, {0}, {1}, {1, 0}}
+	if !reflect.DeepEqual(v.paths, expPaths) {
+		t.Errorf("Expected paths %v, got %v", expPaths, v.paths)
+	}
+	expRings := []int{-1, 0, 0, 0, 0, 1, 1, 1, 1}
+	if !reflect.DeepEqual(v.rings, expRings) {
+		t.Errorf("Expected rings %v, got %v", expRings, v.rings)
+	}
+	if len(v.coords) != 9 || v.coords[0] != (Coord{1, 2}) || v.coords[5] != (Coord{1, 1}) {
+		t.Errorf("Unexpected coords %v", v.coords)
+	}
+}
+
+func TestWalkSkip(t *testing.T) {
+	v := &recordingVisitor{skip: MULTIPOLYGON}
+	if err := walkTestCollection(t).Walk(v); err != nil {
+		t.Fatal(err)
+	}
+	if len(v.coords) != 1 {
+		t.Errorf("Expected only the point's coord, got %v", v.coords)
+	}
+}
+
+func TestEachCoordStops(t *testing.T) {
+	var seen []Coord
+	err := walkTestCollection(t).EachCoord(func(path Path, i int, c Coord) bool {
+		seen = append(seen, c)
+		return len(seen) < 3
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(seen) != 3 {
+		t.Errorf("Expected iteration to stop after 3 coords, got %d", len(seen))
+	}
+}